@@ -0,0 +1,72 @@
+package env
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// ByteSize is an amount of bytes.
+type ByteSize uint64
+
+// Decimal (SI) byte size units.
+const (
+	Byte     ByteSize = 1
+	Kilobyte          = 1000 * Byte
+	Megabyte          = 1000 * Kilobyte
+	Gigabyte          = 1000 * Megabyte
+	Terabyte          = 1000 * Gigabyte
+)
+
+// Binary (IEC) byte size units.
+const (
+	Kibibyte = 1024 * Byte
+	Mebibyte = 1024 * Kibibyte
+	Gibibyte = 1024 * Mebibyte
+	Tebibyte = 1024 * Gibibyte
+)
+
+var byteSizeUnits = map[string]ByteSize{
+	"":    Byte,
+	"B":   Byte,
+	"KB":  Kilobyte,
+	"MB":  Megabyte,
+	"GB":  Gigabyte,
+	"TB":  Terabyte,
+	"KIB": Kibibyte,
+	"MIB": Mebibyte,
+	"GIB": Gibibyte,
+	"TIB": Tebibyte,
+}
+
+// ParseByteSize parses a byte size like "512", "10MB" or "1.5GiB".
+// Units are case insensitive. KB, MB, GB and TB are decimal, while KiB, MiB, GiB and TiB are binary.
+func ParseByteSize(s string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(s)
+	unitStart := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if unitStart < 0 {
+		unitStart = len(trimmed)
+	}
+
+	amount, err := strconv.ParseFloat(trimmed[:unitStart], 64)
+	unit, ok := byteSizeUnits[strings.ToUpper(strings.TrimSpace(trimmed[unitStart:]))]
+	if err != nil || !ok {
+		return 0, errors.New("invalid byte size '%s'", s).WithKind(errors.KindInvalidInput)
+	}
+
+	return ByteSize(amount * float64(unit)), nil
+}
+
+// UnmarshalText parses a byte size like "512", "10MB" or "1.5GiB" into the ByteSize.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = size
+	return nil
+}
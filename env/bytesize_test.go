@@ -0,0 +1,38 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/trivelaapp/go-kit/env"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tt := []struct {
+		input         string
+		expectedValue env.ByteSize
+		expectedErr   bool
+	}{
+		{input: "512", expectedValue: 512},
+		{input: "512B", expectedValue: 512},
+		{input: "10KB", expectedValue: 10 * env.Kilobyte},
+		{input: "10 mb", expectedValue: 10 * env.Megabyte},
+		{input: "1.5GiB", expectedValue: 1536 * env.Mebibyte},
+		{input: "2TiB", expectedValue: 2 * env.Tebibyte},
+		{input: "MB", expectedErr: true},
+		{input: "10XB", expectedErr: true},
+		{input: "-10MB", expectedErr: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			res, err := env.ParseByteSize(tc.input)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Unexpected error result: %v", err)
+			}
+
+			if res != tc.expectedValue {
+				t.Errorf("Mismatch response. Expected '%d', got '%d'.", tc.expectedValue, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// LoadDotEnv loads environment variables from the .env files of the given directory (the working directory by default):
+// the environment specific one (like .env.production) and the base .env file. Missing files are ignored.
+//
+// Variables that are already set are never overwritten, so the process environment takes precedence over the
+// environment specific file, which takes precedence over the base file:
+//
+//	env.LoadDotEnv(env.GetEnvironmentByLabel(os.Getenv("APP_ENV")))
+func LoadDotEnv(environment Environment, dir ...string) error {
+	baseDir := ""
+	if len(dir) > 0 {
+		baseDir = dir[0]
+	}
+
+	base, err := readDotEnvIfExists(filepath.Join(baseDir, ".env"), os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	// Base values can be interpolated by the environment specific file, but can't override the process environment.
+	specific, err := readDotEnvIfExists(filepath.Join(baseDir, ".env."+environment.String()), func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := base[name]
+		return value, ok
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, values := range []map[string]string{specific, base} {
+		for name, value := range values {
+			if _, ok := os.LookupEnv(name); ok {
+				continue
+			}
+
+			if err := os.Setenv(name, value); err != nil {
+				return errors.New("could not set environment variable %s", name).WithRootError(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReadDotEnv reads the variables declared in a .env file, without loading them into the process environment.
+// ${VAR} references are interpolated with the process environment, or with variables previously declared in the file.
+func ReadDotEnv(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("could not read %s", path).WithRootError(err)
+	}
+
+	return parseDotEnv(path, string(content), os.LookupEnv)
+}
+
+func readDotEnvIfExists(path string, lookup func(string) (string, bool)) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, errors.New("could not read %s", path).WithRootError(err)
+	}
+
+	return parseDotEnv(path, string(content), lookup)
+}
+
+// parseDotEnv parses the content of a .env file. It supports:
+//   - blank lines and comments, starting with #;
+//   - an optional "export " prefix;
+//   - unquoted values, with trailing comments;
+//   - double quoted values, that can span multiple lines and contain \n, \t, \", \\ and \$ escapes;
+//   - single quoted values, that can span multiple lines and are read literally;
+//   - ${VAR} interpolation, in unquoted and double quoted values.
+func parseDotEnv(path, content string, lookup func(string) (string, bool)) (map[string]string, error) {
+	values := map[string]string{}
+	resolve := func(name string) string {
+		if value, ok := lookup(name); ok {
+			return value
+		}
+		return values[name]
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, invalidDotEnvError(path, lineNumber, "expected a NAME=value declaration")
+		}
+
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`), strings.HasPrefix(value, `'`):
+			quote := value[0]
+
+			quoted, consumed, err := readQuoted(value[1:], lines[i+1:], quote)
+			if err != nil {
+				return nil, invalidDotEnvError(path, lineNumber, err.Error())
+			}
+			i += consumed
+
+			if quote == '\'' {
+				values[name] = quoted
+			} else {
+				values[name] = expand(quoted, resolve, true)
+			}
+		default:
+			if idx := strings.Index(value, " #"); idx >= 0 {
+				value = strings.TrimSpace(value[:idx])
+			}
+			values[name] = expand(value, resolve, false)
+		}
+	}
+
+	return values, nil
+}
+
+// readQuoted reads a quoted value until its closing quote, consuming the next lines if needed.
+// It returns the raw quoted value and how many of the next lines were consumed.
+func readQuoted(text string, next []string, quote byte) (string, int, error) {
+	consumed := 0
+	for {
+		if end := closingQuote(text, quote); end >= 0 {
+			rest := strings.TrimSpace(text[end+1:])
+			if rest != "" && !strings.HasPrefix(rest, "#") {
+				return "", 0, fmt.Errorf("unexpected characters after quoted value")
+			}
+			return text[:end], consumed, nil
+		}
+
+		if consumed == len(next) {
+			return "", 0, fmt.Errorf("unterminated quoted value")
+		}
+
+		text += "\n" + next[consumed]
+		consumed++
+	}
+}
+
+func closingQuote(text string, quote byte) int {
+	for i := 0; i < len(text); i++ {
+		if quote == '"' && text[i] == '\\' {
+			i++
+			continue
+		}
+		if text[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+// expand interpolates ${VAR} references of the given value and, optionally, unescapes it.
+func expand(value string, resolve func(string) string, unescape bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if unescape && c == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\', '$':
+				b.WriteByte(value[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+
+		if c == '$' && strings.HasPrefix(value[i+1:], "{") {
+			if end := strings.IndexByte(value[i:], '}'); end >= 0 {
+				b.WriteString(resolve(value[i+2 : i+end]))
+				i += end
+				continue
+			}
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+func invalidDotEnvError(path string, line int, reason string) error {
+	return errors.New("invalid %s at line %d: %s", path, line, reason).WithKind(errors.KindInvalidInput)
+}
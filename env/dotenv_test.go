@@ -0,0 +1,129 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+}
+
+func unsetAfterTest(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		for _, name := range names {
+			_ = os.Unsetenv(name)
+		}
+	})
+}
+
+func TestReadDotEnv(t *testing.T) {
+	t.Setenv("DOTENV_PROCESS", "process")
+
+	path := filepath.Join(t.TempDir(), ".env")
+	writeFile(t, path, `
+# comment
+DOTENV_PLAIN=plain value # trailing comment
+export DOTENV_EXPORTED=exported
+DOTENV_DOUBLE="double \"quoted\"\tvalue # not a comment"
+DOTENV_SINGLE='single ${DOTENV_PLAIN} \n'
+DOTENV_MULTILINE="first line
+second line"
+DOTENV_INTERPOLATED=${DOTENV_PLAIN}/${DOTENV_PROCESS}/${DOTENV_MISSING}
+DOTENV_ESCAPED="\${DOTENV_PLAIN}"
+DOTENV_EMPTY=
+`)
+
+	values, err := env.ReadDotEnv(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"DOTENV_PLAIN":        "plain value",
+		"DOTENV_EXPORTED":     "exported",
+		"DOTENV_DOUBLE":       "double \"quoted\"\tvalue # not a comment",
+		"DOTENV_SINGLE":       `single ${DOTENV_PLAIN} \n`,
+		"DOTENV_MULTILINE":    "first line\nsecond line",
+		"DOTENV_INTERPOLATED": "plain value/process/",
+		"DOTENV_ESCAPED":      "${DOTENV_PLAIN}",
+		"DOTENV_EMPTY":        "",
+	}
+	if !reflect.DeepEqual(expected, values) {
+		t.Errorf("Mismatch response. Expected '%v', got '%v'.", expected, values)
+	}
+}
+
+func TestReadDotEnvInvalid(t *testing.T) {
+	tt := []struct {
+		desc        string
+		content     string
+		expectedMsg string
+	}{
+		{
+			desc:        "should fail on declarations without value",
+			content:     "OK=1\nNOT_A_DECLARATION",
+			expectedMsg: "at line 2: expected a NAME=value declaration",
+		},
+		{
+			desc:        "should fail on unterminated quotes",
+			content:     "UNTERMINATED=\"value\nOK=1",
+			expectedMsg: "at line 1: unterminated quoted value",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			writeFile(t, path, tc.content)
+
+			_, err := env.ReadDotEnv(path)
+			if errors.Kind(err) != errors.KindInvalidInput {
+				t.Fatalf("Wrong error kind, got: %s", errors.Kind(err))
+			}
+
+			if expectedMsg := "invalid " + path + " " + tc.expectedMsg; err.Error() != expectedMsg {
+				t.Errorf("Mismatch error message. Expected '%s', got '%s'.", expectedMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".env"), "DOTENV_A=base\nDOTENV_B=base\nDOTENV_C=base\nDOTENV_HOST=localhost")
+	writeFile(t, filepath.Join(dir, ".env.test"), "DOTENV_B=test\nDOTENV_C=test\nDOTENV_URL=http://${DOTENV_HOST}")
+
+	t.Setenv("DOTENV_C", "process")
+	unsetAfterTest(t, "DOTENV_A", "DOTENV_B", "DOTENV_HOST", "DOTENV_URL")
+
+	if err := env.LoadDotEnv(env.GetEnvironmentByLabel("staging"), dir); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"DOTENV_A":   "base",
+		"DOTENV_B":   "test",
+		"DOTENV_C":   "process",
+		"DOTENV_URL": "http://localhost",
+	}
+	for name, value := range expected {
+		if res := os.Getenv(name); res != value {
+			t.Errorf("Mismatch %s value. Expected '%s', got '%s'.", name, value, res)
+		}
+	}
+}
+
+func TestLoadDotEnvMissingFiles(t *testing.T) {
+	if err := env.LoadDotEnv(env.ProductionEnvironment, t.TempDir()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -1,10 +1,9 @@
 package env
 
 import (
-	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"encoding"
+	"net/url"
+	"time"
 )
 
 // Environment is a constant that defines the context in which the application is running.
@@ -21,93 +20,179 @@ const (
 	ProductionEnvironment
 )
 
+// environments lists all Environments.
+var environments = []Environment{DevelopmentEnvironment, TestEnvironment, ProductionEnvironment}
+
+// String returns the canonical label of the Environment, like "production".
+func (e Environment) String() string {
+	switch e {
+	case TestEnvironment:
+		return "test"
+	case ProductionEnvironment:
+		return "production"
+	default:
+		return "development"
+	}
+}
+
 // GetEnvironmentByLabel translates an environment label into its respective Environment constant.
+// Unknown labels are translated into DevelopmentEnvironment, so ParseEnvironment should be preferred, since it rejects them.
 func GetEnvironmentByLabel(label string) Environment {
-	lower := strings.ToLower(label)
-
-	return map[string]Environment{
-		"development": DevelopmentEnvironment,
-		"dev":         DevelopmentEnvironment,
-		"test":        TestEnvironment,
-		"homolog":     TestEnvironment,
-		"staging":     TestEnvironment,
-		"production":  ProductionEnvironment,
-		"prod":        ProductionEnvironment,
-	}[lower]
+	environment, _ := ParseEnvironment(label)
+	return environment
 }
 
 // GetString extracts a String value from the given environment variable.
 func GetString(name string, defaultValue ...string) string {
-	value := os.Getenv(name)
-	if value == "" && len(defaultValue) > 0 {
-		value = defaultValue[0]
-	}
-	return value
+	return defaultLoader.GetString(name, defaultValue...)
 }
 
 // MustGetString extracts a String value from the given environment variable.
-// It panics if not present.
-func MustGetString(name string) string {
-	value := os.Getenv(name)
-	if value == "" {
-		panic(fmt.Sprintf("%s can't be empty", name))
-	}
-	return value
+// It panics if not present, or if any of the given rules is broken.
+func MustGetString(name string, rules ...Rule) string {
+	return defaultLoader.MustGetString(name, rules...)
 }
 
 // GetInt extracts an Int value from the given environment variable.
 func GetInt(name string, defaultValue ...int) int {
-	value, err := strconv.Atoi(os.Getenv(name))
-	if err != nil && len(defaultValue) > 0 {
-		value = defaultValue[0]
-	}
-	return value
+	return defaultLoader.GetInt(name, defaultValue...)
 }
 
 // MustGetInt extracts an Int value from the given environment variable.
-// It exits the application if not present.
-func MustGetInt(name string) int {
-	value, err := strconv.Atoi(os.Getenv(name))
-	if err != nil {
-		panic(fmt.Sprintf("%s must contain an int value", name))
-	}
-	return value
+// It exits the application if not present, or if any of the given rules is broken.
+func MustGetInt(name string, rules ...Rule) int {
+	return defaultLoader.MustGetInt(name, rules...)
 }
 
 // GetFloat extracts a Float value from the given environment variable.
 func GetFloat(name string, defaultValue ...float64) float64 {
-	value, err := strconv.ParseFloat(os.Getenv(name), 64)
-	if err != nil && len(defaultValue) > 0 {
-		value = defaultValue[0]
-	}
-	return value
+	return defaultLoader.GetFloat(name, defaultValue...)
 }
 
 // MustGetFloat extracts a Float value from the given environment variable.
-// It exits the application if not present.
-func MustGetFloat(name string) float64 {
-	value, err := strconv.ParseFloat(os.Getenv(name), 64)
-	if err != nil {
-		panic(fmt.Sprintf("%s must contain a float value", name))
-	}
-	return value
+// It exits the application if not present, or if any of the given rules is broken.
+func MustGetFloat(name string, rules ...Rule) float64 {
+	return defaultLoader.MustGetFloat(name, rules...)
 }
 
 // GetBool extracts a Bool value from the given environment variable.
 func GetBool(name string, defaultValue ...bool) bool {
-	value, err := strconv.ParseBool(os.Getenv(name))
-	if err != nil && len(defaultValue) > 0 {
-		value = defaultValue[0]
-	}
-	return value
+	return defaultLoader.GetBool(name, defaultValue...)
 }
 
 // MustGetBool extracts a Bool value from the given environment variable.
-// It exits the application if not present.
-func MustGetBool(name string) bool {
-	value, err := strconv.ParseBool(os.Getenv(name))
-	if err != nil {
-		panic(fmt.Sprintf("%s must contain a boolean value", name))
-	}
-	return value
+// It exits the application if not present, or if any of the given rules is broken.
+func MustGetBool(name string, rules ...Rule) bool {
+	return defaultLoader.MustGetBool(name, rules...)
+}
+
+// GetDuration extracts a Duration value (like "1m30s") from the given environment variable.
+func GetDuration(name string, defaultValue ...time.Duration) time.Duration {
+	return defaultLoader.GetDuration(name, defaultValue...)
+}
+
+// MustGetDuration extracts a Duration value (like "1m30s") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetDuration(name string, rules ...Rule) time.Duration {
+	return defaultLoader.MustGetDuration(name, rules...)
+}
+
+// GetStringSlice extracts a list of String values (like "a,b,c") from the given environment variable.
+func GetStringSlice(name string, defaultValue ...[]string) []string {
+	return defaultLoader.GetStringSlice(name, defaultValue...)
+}
+
+// MustGetStringSlice extracts a list of String values (like "a,b,c") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetStringSlice(name string, rules ...Rule) []string {
+	return defaultLoader.MustGetStringSlice(name, rules...)
+}
+
+// GetIntSlice extracts a list of Int values (like "1,2,3") from the given environment variable.
+func GetIntSlice(name string, defaultValue ...[]int) []int {
+	return defaultLoader.GetIntSlice(name, defaultValue...)
+}
+
+// MustGetIntSlice extracts a list of Int values (like "1,2,3") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetIntSlice(name string, rules ...Rule) []int {
+	return defaultLoader.MustGetIntSlice(name, rules...)
+}
+
+// GetFloatSlice extracts a list of Float values (like "1.5,2.5") from the given environment variable.
+func GetFloatSlice(name string, defaultValue ...[]float64) []float64 {
+	return defaultLoader.GetFloatSlice(name, defaultValue...)
+}
+
+// MustGetFloatSlice extracts a list of Float values (like "1.5,2.5") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetFloatSlice(name string, rules ...Rule) []float64 {
+	return defaultLoader.MustGetFloatSlice(name, rules...)
+}
+
+// GetBoolSlice extracts a list of Bool values (like "true,false") from the given environment variable.
+func GetBoolSlice(name string, defaultValue ...[]bool) []bool {
+	return defaultLoader.GetBoolSlice(name, defaultValue...)
+}
+
+// MustGetBoolSlice extracts a list of Bool values (like "true,false") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetBoolSlice(name string, rules ...Rule) []bool {
+	return defaultLoader.MustGetBoolSlice(name, rules...)
+}
+
+// GetDurationSlice extracts a list of Duration values (like "1s,5s,1m") from the given environment variable.
+func GetDurationSlice(name string, defaultValue ...[]time.Duration) []time.Duration {
+	return defaultLoader.GetDurationSlice(name, defaultValue...)
+}
+
+// MustGetDurationSlice extracts a list of Duration values (like "1s,5s,1m") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetDurationSlice(name string, rules ...Rule) []time.Duration {
+	return defaultLoader.MustGetDurationSlice(name, rules...)
+}
+
+// GetStringMap extracts a map of String values (like "a=1,b=2") from the given environment variable.
+func GetStringMap(name string, defaultValue ...map[string]string) map[string]string {
+	return defaultLoader.GetStringMap(name, defaultValue...)
+}
+
+// MustGetStringMap extracts a map of String values (like "a=1,b=2") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetStringMap(name string, rules ...Rule) map[string]string {
+	return defaultLoader.MustGetStringMap(name, rules...)
+}
+
+// GetURL extracts an absolute URL value from the given environment variable.
+func GetURL(name string, defaultValue ...*url.URL) *url.URL {
+	return defaultLoader.GetURL(name, defaultValue...)
+}
+
+// MustGetURL extracts an absolute URL value from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetURL(name string, rules ...Rule) *url.URL {
+	return defaultLoader.MustGetURL(name, rules...)
+}
+
+// GetByteSize extracts a ByteSize value (like "10MB") from the given environment variable.
+func GetByteSize(name string, defaultValue ...ByteSize) ByteSize {
+	return defaultLoader.GetByteSize(name, defaultValue...)
+}
+
+// MustGetByteSize extracts a ByteSize value (like "10MB") from the given environment variable.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetByteSize(name string, rules ...Rule) ByteSize {
+	return defaultLoader.MustGetByteSize(name, rules...)
+}
+
+// GetText extracts a value from the given environment variable into target, using its UnmarshalText method.
+// If it fails, the default value is unmarshaled instead.
+func GetText(name string, target encoding.TextUnmarshaler, defaultValue ...string) {
+	defaultLoader.GetText(name, target, defaultValue...)
+}
+
+// MustGetText extracts a value from the given environment variable into target, using its UnmarshalText method.
+// It panics if not present, or if any of the given rules is broken.
+func MustGetText(name string, target encoding.TextUnmarshaler, rules ...Rule) {
+	defaultLoader.MustGetText(name, target, rules...)
 }
@@ -1,8 +1,12 @@
 package env_test
 
 import (
+	"net"
+	"net/url"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/trivelaapp/go-kit/env"
 )
@@ -229,7 +233,7 @@ func TestMustGetInt(t *testing.T) {
 		{
 			desc:             "should panic when environment variable value is not and int",
 			env:              "FAKE_ENV_2",
-			expectedPanicMsg: "FAKE_ENV_2 must contain an int value",
+			expectedPanicMsg: `FAKE_ENV_2 must contain an int value: strconv.ParseInt: parsing "fake-invalid-env": invalid syntax`,
 		},
 		{
 			desc:             "should panic when accessing an unknown environment variable",
@@ -329,7 +333,7 @@ func TestMustGetFloat(t *testing.T) {
 		{
 			desc:             "should panic when accessing an environment variable that isn't a Float",
 			env:              "FAKE_ENV_2",
-			expectedPanicMsg: "FAKE_ENV_2 must contain a float value",
+			expectedPanicMsg: `FAKE_ENV_2 must contain a float value: strconv.ParseFloat: parsing "fake-invalid-env": invalid syntax`,
 		},
 		{
 			desc:             "should panic when accessing an unknown environment variable",
@@ -429,7 +433,7 @@ func TestMustGetBool(t *testing.T) {
 		{
 			desc:             "should panic when accessing an environment variable that isn't a Boolean",
 			env:              "FAKE_ENV_2",
-			expectedPanicMsg: "FAKE_ENV_2 must contain a boolean value",
+			expectedPanicMsg: `FAKE_ENV_2 must contain a boolean value: strconv.ParseBool: parsing "fake-invalid-env": invalid syntax`,
 		},
 		{
 			desc:             "should panic when accessing an unknown environment variable",
@@ -458,3 +462,184 @@ func TestMustGetBool(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDuration(t *testing.T) {
+	t.Setenv("FAKE_DURATION_1", "1m30s")
+	t.Setenv("FAKE_DURATION_2", "fake-invalid-env")
+
+	tt := []struct {
+		desc          string
+		env           string
+		defaultValues []time.Duration
+		expectedValue time.Duration
+	}{
+		{
+			desc:          "should access a valid environment variable successfully",
+			env:           "FAKE_DURATION_1",
+			defaultValues: []time.Duration{time.Second},
+			expectedValue: 90 * time.Second,
+		},
+		{
+			desc:          "should access an unknown environment variable with default value successfully",
+			env:           "FAKE_DURATION_3",
+			defaultValues: []time.Duration{time.Second},
+			expectedValue: time.Second,
+		},
+		{
+			desc:          "should use default value when environment variable is not a Duration",
+			env:           "FAKE_DURATION_2",
+			defaultValues: []time.Duration{time.Second},
+			expectedValue: time.Second,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			res := env.GetDuration(tc.env, tc.defaultValues...)
+			if tc.expectedValue != res {
+				t.Errorf("Mismatch response. Expected '%s', got '%s'.", tc.expectedValue, res)
+			}
+		})
+	}
+}
+
+func TestMustGetDuration(t *testing.T) {
+	t.Setenv("FAKE_DURATION_1", "fake-invalid-env")
+
+	defer func() {
+		expectedPanicMsg := `FAKE_DURATION_1 must contain a duration value: time: invalid duration "fake-invalid-env"`
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	env.MustGetDuration("FAKE_DURATION_1")
+}
+
+func TestGetSlices(t *testing.T) {
+	t.Setenv("FAKE_SLICE_1", "a, b,c")
+	t.Setenv("FAKE_SLICE_2", "1,2,3")
+	t.Setenv("FAKE_SLICE_3", "1.5,2.5")
+	t.Setenv("FAKE_SLICE_4", "true,false")
+	t.Setenv("FAKE_SLICE_5", "1s,1m")
+
+	if res := env.GetStringSlice("FAKE_SLICE_1"); !reflect.DeepEqual([]string{"a", "b", "c"}, res) {
+		t.Errorf("Mismatch string slice response, got '%v'.", res)
+	}
+
+	if res := env.GetIntSlice("FAKE_SLICE_2"); !reflect.DeepEqual([]int{1, 2, 3}, res) {
+		t.Errorf("Mismatch int slice response, got '%v'.", res)
+	}
+
+	if res := env.GetFloatSlice("FAKE_SLICE_3"); !reflect.DeepEqual([]float64{1.5, 2.5}, res) {
+		t.Errorf("Mismatch float slice response, got '%v'.", res)
+	}
+
+	if res := env.GetBoolSlice("FAKE_SLICE_4"); !reflect.DeepEqual([]bool{true, false}, res) {
+		t.Errorf("Mismatch bool slice response, got '%v'.", res)
+	}
+
+	if res := env.GetDurationSlice("FAKE_SLICE_5"); !reflect.DeepEqual([]time.Duration{time.Second, time.Minute}, res) {
+		t.Errorf("Mismatch duration slice response, got '%v'.", res)
+	}
+
+	if res := env.GetIntSlice("FAKE_SLICE_1", []int{7}); !reflect.DeepEqual([]int{7}, res) {
+		t.Errorf("Expected default value when environment variable is not an Int list, got '%v'.", res)
+	}
+
+	defer func() {
+		expectedPanicMsg := `FAKE_SLICE_1 must contain a list of int values: strconv.ParseInt: parsing "a": invalid syntax`
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	env.MustGetIntSlice("FAKE_SLICE_1")
+}
+
+func TestGetStringMap(t *testing.T) {
+	t.Setenv("FAKE_MAP_1", "a=1, b = 2,c=x=y")
+	t.Setenv("FAKE_MAP_2", "a=1,b")
+
+	expected := map[string]string{"a": "1", "b": "2", "c": "x=y"}
+	if res := env.GetStringMap("FAKE_MAP_1"); !reflect.DeepEqual(expected, res) {
+		t.Errorf("Mismatch response. Expected '%v', got '%v'.", expected, res)
+	}
+
+	defer func() {
+		expectedPanicMsg := "FAKE_MAP_2 must contain a map of key=value pairs: map item 'b' must be a key=value pair"
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	env.MustGetStringMap("FAKE_MAP_2")
+}
+
+func TestGetURL(t *testing.T) {
+	t.Setenv("FAKE_URL_1", "https://trivela.com.br/path")
+	t.Setenv("FAKE_URL_2", "trivela.com.br")
+
+	if res := env.GetURL("FAKE_URL_1"); res == nil || res.Host != "trivela.com.br" {
+		t.Errorf("Mismatch response, got '%v'.", res)
+	}
+
+	defaultValue := &url.URL{Scheme: "http", Host: "localhost"}
+	if res := env.GetURL("FAKE_URL_2", defaultValue); res != defaultValue {
+		t.Errorf("Expected default value when environment variable is not an absolute URL, got '%v'.", res)
+	}
+
+	defer func() {
+		expectedPanicMsg := "FAKE_URL_2 must contain an absolute URL value: url 'trivela.com.br' must be absolute"
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	env.MustGetURL("FAKE_URL_2")
+}
+
+func TestGetByteSize(t *testing.T) {
+	t.Setenv("FAKE_BYTE_SIZE_1", "10MB")
+	t.Setenv("FAKE_BYTE_SIZE_2", "10 parsecs")
+
+	if res := env.GetByteSize("FAKE_BYTE_SIZE_1"); res != 10*env.Megabyte {
+		t.Errorf("Mismatch response, got '%d'.", res)
+	}
+
+	if res := env.GetByteSize("FAKE_BYTE_SIZE_2", env.Kibibyte); res != env.Kibibyte {
+		t.Errorf("Expected default value when environment variable is not a ByteSize, got '%d'.", res)
+	}
+
+	defer func() {
+		expectedPanicMsg := "FAKE_BYTE_SIZE_2 must contain a byte size value: invalid byte size '10 parsecs'"
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	env.MustGetByteSize("FAKE_BYTE_SIZE_2")
+}
+
+func TestGetText(t *testing.T) {
+	t.Setenv("FAKE_TEXT_1", "10.0.0.1")
+	t.Setenv("FAKE_TEXT_2", "fake-invalid-env")
+
+	var ip net.IP
+	if env.GetText("FAKE_TEXT_1", &ip); ip.String() != "10.0.0.1" {
+		t.Errorf("Mismatch response, got '%s'.", ip)
+	}
+
+	if env.GetText("FAKE_TEXT_2", &ip, "127.0.0.1"); ip.String() != "127.0.0.1" {
+		t.Errorf("Expected default value when environment variable is not valid, got '%s'.", ip)
+	}
+
+	defer func() {
+		expectedPanicMsg := "FAKE_TEXT_2 must contain a valid net.IP value: invalid IP address: fake-invalid-env"
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	env.MustGetText("FAKE_TEXT_2", &ip)
+}
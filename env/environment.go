@@ -0,0 +1,147 @@
+package env
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// EnvironmentVariable is the environment variable that holds the label of the Environment the application is running in.
+const EnvironmentVariable = "APP_ENV"
+
+var (
+	environmentLabelsMu sync.RWMutex
+	environmentLabels   = map[string]Environment{
+		"development": DevelopmentEnvironment,
+		"dev":         DevelopmentEnvironment,
+		"test":        TestEnvironment,
+		"homolog":     TestEnvironment,
+		"staging":     TestEnvironment,
+		"production":  ProductionEnvironment,
+		"prod":        ProductionEnvironment,
+	}
+)
+
+// RegisterEnvironmentLabel registers a custom label (like "qa") for the given Environment. Labels are case insensitive.
+// It fails if the label is already registered for another Environment.
+func RegisterEnvironmentLabel(label string, environment Environment) error {
+	lower := strings.ToLower(label)
+
+	environmentLabelsMu.Lock()
+	defer environmentLabelsMu.Unlock()
+
+	if registered, ok := environmentLabels[lower]; ok && registered != environment {
+		return errors.New("environment label '%s' is already registered for %s", label, registered).WithKind(errors.KindConflict)
+	}
+
+	environmentLabels[lower] = environment
+	return nil
+}
+
+// ParseEnvironment translates an environment label into its respective Environment constant.
+// Unlike GetEnvironmentByLabel, it fails on unknown labels, so typos like "prodution" are not silently taken as DevelopmentEnvironment.
+func ParseEnvironment(label string) (Environment, error) {
+	environmentLabelsMu.RLock()
+	defer environmentLabelsMu.RUnlock()
+
+	environment, ok := environmentLabels[strings.ToLower(label)]
+	if !ok {
+		return DevelopmentEnvironment, errors.New("unknown environment label '%s'", label).
+			WithKind(errors.Kind(ErrUnknownEnvironment)).
+			WithCode(errors.Code(ErrUnknownEnvironment))
+	}
+
+	return environment, nil
+}
+
+// Current returns the Environment the application is running in, from the label held by the APP_ENV environment variable.
+// It returns DevelopmentEnvironment when the variable is empty, and fails on unknown labels.
+func Current() (Environment, error) {
+	return defaultLoader.Environment()
+}
+
+// MustCurrent returns the Environment the application is running in, as Current does.
+// It panics on unknown labels.
+func MustCurrent() Environment {
+	environment, err := Current()
+	if err != nil {
+		panic(err.Error())
+	}
+	return environment
+}
+
+// Environment returns the Environment held by the APP_ENV variable of the Loader Source, as the package Current function does.
+func (l *Loader) Environment() (Environment, error) {
+	label, _, _ := l.resolve(EnvironmentVariable)
+	if label == "" {
+		return DevelopmentEnvironment, nil
+	}
+
+	return ParseEnvironment(label)
+}
+
+// SetEnvironmentDefaults sets the default values of variables in the given Environment, used by the package getters
+// when the variables are empty and the application is running in that Environment (see Current).
+// They take precedence over the default values given to the getters, which are kept for the other environments:
+//
+//	env.SetEnvironmentDefaults(env.ProductionEnvironment, map[string]string{"WORKERS": "16"})
+//	workers := env.GetInt("WORKERS", 4) // 16 in production, 4 elsewhere
+func SetEnvironmentDefaults(environment Environment, defaults map[string]string) {
+	defaultLoader.SetEnvironmentDefaults(environment, defaults)
+}
+
+// SetEnvironmentDefaults sets the default values of variables in the given Environment, used by the Loader getters,
+// as the package SetEnvironmentDefaults function does.
+func (l *Loader) SetEnvironmentDefaults(environment Environment, defaults map[string]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.environmentDefaults[environment] == nil {
+		l.environmentDefaults[environment] = map[string]string{}
+	}
+
+	for name, value := range defaults {
+		l.environmentDefaults[environment][name] = value
+	}
+}
+
+// environmentDefault returns the default value of the given variable in the Environment the Loader is running in.
+// The Environment is only resolved when there are defaults, so unknown environments don't affect Loaders without them.
+func (l *Loader) environmentDefault(name string) (string, error) {
+	l.mu.RLock()
+	empty := len(l.environmentDefaults) == 0
+	l.mu.RUnlock()
+
+	if empty {
+		return "", nil
+	}
+
+	environment, err := l.Environment()
+	if err != nil {
+		return "", err
+	}
+
+	l.mu.RLock()
+	value := l.environmentDefaults[environment][name]
+	l.mu.RUnlock()
+
+	if value != "" {
+		l.trackDefault(name, value)
+	}
+
+	return value, nil
+}
+
+// ByEnvironment returns the value of the given Environment, or the fallback when it has none.
+// It avoids if/else chains when defaults differ per environment:
+//
+//	workers := env.GetInt("WORKERS", env.ByEnvironment(env.MustCurrent(), map[env.Environment]int{
+//		env.ProductionEnvironment: 16,
+//	}, 4))
+func ByEnvironment[T any](environment Environment, values map[Environment]T, fallback T) T {
+	if value, ok := values[environment]; ok {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,221 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	if err := env.RegisterEnvironmentLabel("QA", env.TestEnvironment); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tt := []struct {
+		desc          string
+		input         string
+		expectedEnv   env.Environment
+		expectedError bool
+	}{
+		{desc: "should parse default labels", input: "PRODUCTION", expectedEnv: env.ProductionEnvironment},
+		{desc: "should parse custom labels", input: "qa", expectedEnv: env.TestEnvironment},
+		{desc: "should fail on typos", input: "prodution", expectedEnv: env.DevelopmentEnvironment, expectedError: true},
+		{desc: "should fail on empty labels", input: "", expectedEnv: env.DevelopmentEnvironment, expectedError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := env.ParseEnvironment(tc.input)
+			if res != tc.expectedEnv {
+				t.Errorf("Mismatch response, got %s", res)
+			}
+
+			if tc.expectedError && errors.Code(err) != errors.Code(env.ErrUnknownEnvironment) {
+				t.Errorf("Wrong error code, got: %s", errors.Code(err))
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("should fail when registering a label of another environment", func(t *testing.T) {
+		if err := env.RegisterEnvironmentLabel("prod", env.TestEnvironment); errors.Kind(err) != errors.KindConflict {
+			t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+		}
+	})
+}
+
+func TestCurrent(t *testing.T) {
+	t.Run("should default to development", func(t *testing.T) {
+		t.Setenv(env.EnvironmentVariable, "")
+
+		if res := env.MustCurrent(); res != env.DevelopmentEnvironment {
+			t.Errorf("Mismatch response, got %s", res)
+		}
+	})
+
+	t.Run("should read APP_ENV", func(t *testing.T) {
+		t.Setenv(env.EnvironmentVariable, "prod")
+
+		if res := env.MustCurrent(); res != env.ProductionEnvironment {
+			t.Errorf("Mismatch response, got %s", res)
+		}
+	})
+
+	t.Run("should panic on unknown labels", func(t *testing.T) {
+		t.Setenv(env.EnvironmentVariable, "prodution")
+
+		defer func() {
+			expectedPanicMsg := "unknown environment label 'prodution'"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+			}
+		}()
+
+		env.MustCurrent()
+	})
+}
+
+func TestByEnvironment(t *testing.T) {
+	values := map[env.Environment]int{env.ProductionEnvironment: 16}
+
+	if res := env.ByEnvironment(env.ProductionEnvironment, values, 4); res != 16 {
+		t.Errorf("Mismatch production response, got %d", res)
+	}
+
+	if res := env.ByEnvironment(env.TestEnvironment, values, 4); res != 4 {
+		t.Errorf("Mismatch fallback response, got %d", res)
+	}
+}
+
+func TestLoadPerEnvironmentDefaults(t *testing.T) {
+	type config struct {
+		Workers int    `env:"WORKERS" default:"4" default_production:"16"`
+		Level   string `env:"LEVEL" default:"DEBUG" default_test:"INFO" default_production:"WARNING"`
+	}
+
+	tt := []struct {
+		desc     string
+		appEnv   string
+		expected config
+	}{
+		{desc: "development", appEnv: "", expected: config{Workers: 4, Level: "DEBUG"}},
+		{desc: "test", appEnv: "staging", expected: config{Workers: 4, Level: "INFO"}},
+		{desc: "production", appEnv: "prod", expected: config{Workers: 16, Level: "WARNING"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			loader := env.NewLoader(env.LoaderParams{Source: env.MapSource{env.EnvironmentVariable: tc.appEnv}})
+
+			var cfg config
+			if err := loader.Load(&cfg); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if cfg != tc.expected {
+				t.Errorf("Mismatch response. Expected '%+v', got '%+v'.", tc.expected, cfg)
+			}
+		})
+	}
+
+	t.Run("should report unknown environments", func(t *testing.T) {
+		loader := env.NewLoader(env.LoaderParams{Source: env.MapSource{env.EnvironmentVariable: "prodution"}})
+
+		var cfg config
+		violations := errors.Violations(loader.Load(&cfg))
+		if len(violations) != 1 || violations[0].Field != env.EnvironmentVariable {
+			t.Errorf("Mismatch violations, got '%+v'.", violations)
+		}
+	})
+
+	t.Run("should ignore unknown environments when there are no per environment defaults", func(t *testing.T) {
+		loader := env.NewLoader(env.LoaderParams{Source: env.MapSource{env.EnvironmentVariable: "prodution"}})
+
+		var cfg struct {
+			Workers int `env:"WORKERS" default:"4"`
+		}
+		if err := loader.Load(&cfg); err != nil || cfg.Workers != 4 {
+			t.Errorf("Expected configuration to be loaded, got '%+v' and error '%v'.", cfg, err)
+		}
+	})
+}
+
+func TestSetEnvironmentDefaults(t *testing.T) {
+	tt := []struct {
+		desc            string
+		source          env.MapSource
+		expectedWorkers int
+		expectedLevel   string
+	}{
+		{
+			desc:            "should use the defaults of the current environment",
+			source:          env.MapSource{env.EnvironmentVariable: "prod"},
+			expectedWorkers: 16,
+			expectedLevel:   "WARNING",
+		},
+		{
+			desc:            "should use the getter defaults in environments without defaults",
+			source:          env.MapSource{env.EnvironmentVariable: "test"},
+			expectedWorkers: 4,
+			expectedLevel:   "DEBUG",
+		},
+		{
+			desc:            "should use the variable values over any default",
+			source:          env.MapSource{env.EnvironmentVariable: "production", "WORKERS": "8"},
+			expectedWorkers: 8,
+			expectedLevel:   "WARNING",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			loader := env.NewLoader(env.LoaderParams{Source: tc.source})
+			loader.SetEnvironmentDefaults(env.ProductionEnvironment, map[string]string{"WORKERS": "16", "LEVEL": "WARNING"})
+
+			if res := loader.GetInt("WORKERS", 4); res != tc.expectedWorkers {
+				t.Errorf("Mismatch workers response. Expected '%d', got '%d'.", tc.expectedWorkers, res)
+			}
+
+			if res := loader.GetString("LEVEL", "DEBUG"); res != tc.expectedLevel {
+				t.Errorf("Mismatch level response. Expected '%s', got '%s'.", tc.expectedLevel, res)
+			}
+		})
+	}
+
+	t.Run("should report per environment defaults", func(t *testing.T) {
+		loader := env.NewLoader(env.LoaderParams{Source: env.MapSource{env.EnvironmentVariable: "production"}})
+		loader.SetEnvironmentDefaults(env.ProductionEnvironment, map[string]string{"WORKERS": "16"})
+
+		if res := loader.MustGetInt("WORKERS"); res != 16 {
+			t.Errorf("Mismatch workers response, got '%d'.", res)
+		}
+
+		for _, entry := range loader.GetReport() {
+			if entry.Name == "WORKERS" && (entry.Value != "16" || entry.Origin != env.OriginDefault) {
+				t.Errorf("Mismatch report entry, got '%+v'.", entry)
+			}
+		}
+	})
+
+	t.Run("should panic on unknown environments when requiring values", func(t *testing.T) {
+		loader := env.NewLoader(env.LoaderParams{Source: env.MapSource{env.EnvironmentVariable: "prodution"}})
+		loader.SetEnvironmentDefaults(env.ProductionEnvironment, map[string]string{"WORKERS": "16"})
+
+		if res := loader.GetInt("WORKERS", 4); res != 4 {
+			t.Errorf("Expected getter default on unknown environments, got '%d'.", res)
+		}
+
+		defer func() {
+			expectedPanicMsg := "unknown environment label 'prodution'"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%v'.", expectedPanicMsg, e)
+			}
+		}()
+
+		loader.MustGetInt("WORKERS")
+	})
+}
@@ -0,0 +1,255 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// Variable describes an environment variable declared by a configuration struct (see Load).
+type Variable struct {
+	Name    string
+	Type    string
+	Default string
+	// EnvironmentDefaults are the defaults declared by default_<environment> tags, which override Default in their environments.
+	EnvironmentDefaults map[Environment]string
+	Required            bool
+	Sensitive           bool
+	// Rules are the validation rules declared by tags, like "min=1" or "notempty".
+	Rules       []string
+	Description string
+}
+
+// Describe lists the environment variables declared by the tags of a configuration struct, in declaration order.
+// Besides the tags supported by Load, it reads the description tag.
+func Describe(cfg any) ([]Variable, error) {
+	t := reflect.TypeOf(cfg)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("configuration must be a struct or a pointer to a struct, got %T", cfg).WithKind(errors.KindInvalidInput)
+	}
+
+	return describeStruct(t, ""), nil
+}
+
+func describeStruct(t reflect.Type, prefix string) []Variable {
+	variables := []Variable{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			if nested, ok := nestedStructType(field.Type); ok {
+				variables = append(variables, describeStruct(nested, prefix+field.Tag.Get("prefix"))...)
+			}
+			continue
+		}
+
+		variables = append(variables, Variable{
+			Name:                prefix + name,
+			Type:                field.Type.String(),
+			Default:             field.Tag.Get("default"),
+			EnvironmentDefaults: environmentDefaultTags(field),
+			Required:            field.Tag.Get("required") == "true",
+			Sensitive:           field.Tag.Get("sensitive") == "true",
+			Rules:               ruleTags(field),
+			Description:         field.Tag.Get("description"),
+		})
+	}
+
+	return variables
+}
+
+// environmentDefaultTags reads the default_<environment> tags of a struct field. It returns nil when there are none.
+func environmentDefaultTags(field reflect.StructField) map[Environment]string {
+	var defaults map[Environment]string
+	for _, environment := range environments {
+		if value, ok := field.Tag.Lookup("default_" + environment.String()); ok {
+			if defaults == nil {
+				defaults = map[Environment]string{}
+			}
+			defaults[environment] = value
+		}
+	}
+	return defaults
+}
+
+// environmentDefaultsNotes describes the per environment defaults of a variable, like "production: 16".
+// Values are formatted by the given function.
+func environmentDefaultsNotes(variable Variable, format func(string) string) []string {
+	notes := []string{}
+	for _, environment := range environments {
+		if value, ok := variable.EnvironmentDefaults[environment]; ok {
+			notes = append(notes, fmt.Sprintf("%s: %s", environment, format(value)))
+		}
+	}
+	return notes
+}
+
+// GenerateDotEnvExample generates a .env.example file from a configuration struct, with its variables descriptions as comments.
+// Sensitive variables are left empty.
+func GenerateDotEnvExample(cfg any) (string, error) {
+	variables, err := Describe(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i, variable := range variables {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		comment := []string{}
+		if variable.Description != "" {
+			comment = append(comment, strings.TrimSuffix(variable.Description, ".")+".")
+		}
+		comment = append(comment, fmt.Sprintf("Type: %s.", variable.Type))
+		if variable.Required {
+			comment = append(comment, "Required.")
+		}
+		if len(variable.Rules) > 0 {
+			comment = append(comment, fmt.Sprintf("Rules: %s.", strings.Join(variable.Rules, ", ")))
+		}
+		if notes := environmentDefaultsNotes(variable, quoteDotEnvValue); len(notes) > 0 && !variable.Sensitive {
+			comment = append(comment, fmt.Sprintf("Defaults by environment: %s.", strings.Join(notes, ", ")))
+		}
+		writeComment(&b, "", strings.Join(comment, " "))
+
+		value := variable.Default
+		if variable.Sensitive {
+			value = ""
+		}
+		fmt.Fprintf(&b, "%s=%s\n", variable.Name, quoteDotEnvValue(value))
+	}
+
+	return b.String(), nil
+}
+
+// GenerateMarkdown generates a Markdown table with the variables of a configuration struct:
+// their name, type, default values, whether they are required, validation rules and description.
+func GenerateMarkdown(cfg any) (string, error) {
+	variables, err := Describe(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("| Name | Type | Default | Required | Rules | Description |\n")
+	b.WriteString("| ---- | ---- | ------- | -------- | ----- | ----------- |\n")
+	for _, variable := range variables {
+		defaults := []string{}
+		if variable.Default != "" {
+			defaults = append(defaults, markdownCode(variable.Default))
+		}
+		defaults = append(defaults, environmentDefaultsNotes(variable, markdownCode)...)
+
+		rules := []string{}
+		for _, rule := range variable.Rules {
+			rules = append(rules, markdownCode(rule))
+		}
+
+		required := "no"
+		if variable.Required {
+			required = "yes"
+		}
+
+		fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s | %s | %s |\n",
+			variable.Name,
+			variable.Type,
+			errors.EscapeMarkdownCell(strings.Join(defaults, ", ")),
+			required,
+			errors.EscapeMarkdownCell(strings.Join(rules, ", ")),
+			errors.EscapeMarkdownCell(variable.Description),
+		)
+	}
+
+	return b.String(), nil
+}
+
+// GenerateKubernetes generates the skeleton of a Kubernetes ConfigMap, with the variables of a configuration struct and their defaults,
+// and of a Secret, with its sensitive variables left empty. Both are named after the given name.
+// Descriptions, validation rules and per environment defaults are written as comments.
+func GenerateKubernetes(cfg any, name string) (string, error) {
+	variables, err := Describe(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	configMap, secret := []Variable{}, []Variable{}
+	for _, variable := range variables {
+		if variable.Sensitive {
+			secret = append(secret, variable)
+		} else {
+			configMap = append(configMap, variable)
+		}
+	}
+
+	documents := []string{}
+	if len(configMap) > 0 {
+		documents = append(documents, kubernetesDocument("ConfigMap", name, "data", configMap, true))
+	}
+	if len(secret) > 0 {
+		documents = append(documents, kubernetesDocument("Secret", name, "stringData", secret, false))
+	}
+
+	return strings.Join(documents, "---\n"), nil
+}
+
+func kubernetesDocument(kind, name, dataKey string, variables []Variable, withDefaults bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "apiVersion: v1\nkind: %s\nmetadata:\n  name: %s\n", kind, name)
+	if kind == "Secret" {
+		b.WriteString("type: Opaque\n")
+	}
+
+	fmt.Fprintf(&b, "%s:\n", dataKey)
+	for _, variable := range variables {
+		if variable.Description != "" {
+			writeComment(&b, "  ", variable.Description)
+		}
+		if len(variable.Rules) > 0 {
+			writeComment(&b, "  ", fmt.Sprintf("Rules: %s.", strings.Join(variable.Rules, ", ")))
+		}
+		if notes := environmentDefaultsNotes(variable, strconv.Quote); len(notes) > 0 && withDefaults {
+			writeComment(&b, "  ", fmt.Sprintf("Defaults by environment: %s.", strings.Join(notes, ", ")))
+		}
+
+		value := ""
+		if withDefaults {
+			value = variable.Default
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", variable.Name, strconv.Quote(value))
+	}
+
+	return b.String()
+}
+
+// writeComment writes the given text as comment lines, so multi-line texts don't break .env and YAML files.
+func writeComment(b *strings.Builder, indent, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "%s# %s\n", indent, strings.TrimRight(line, " \t\r"))
+	}
+}
+
+func quoteDotEnvValue(value string) string {
+	if strings.ContainsAny(value, " #\"'\\$\n\t") {
+		// Double quoted values are unescaped by the .env parser, but $ must be escaped to avoid interpolation.
+		return strings.ReplaceAll(strconv.Quote(value), "$", "\\$")
+	}
+	return value
+}
+
+func markdownCode(value string) string {
+	return "`" + value + "`"
+}
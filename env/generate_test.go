@@ -0,0 +1,178 @@
+package env_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+type generateTestConfig struct {
+	Port     int           `env:"PORT" default:"8080" min:"1" max:"65535" description:"HTTP server port"`
+	Timeout  time.Duration `env:"TIMEOUT" default:"5s"`
+	Greeting string        `env:"GREETING" default:"hello $USER | #1"`
+	Workers  int           `env:"WORKERS" default:"4" default_production:"16" description:"Number of workers.\nKeep it close to the CPU count."`
+	Level    string        `env:"LEVEL" default:"INFO" oneof:"DEBUG, INFO" notempty:"true"`
+	Database struct {
+		URL      string `env:"URL" required:"true" description:"Database connection URL."`
+		Password string `env:"PASSWORD" required:"true" sensitive:"true" description:"Database password"`
+	} `prefix:"DB_"`
+}
+
+func TestDescribe(t *testing.T) {
+	expected := []env.Variable{
+		{Name: "PORT", Type: "int", Default: "8080", Rules: []string{"min=1", "max=65535"}, Description: "HTTP server port"},
+		{Name: "TIMEOUT", Type: "time.Duration", Default: "5s"},
+		{Name: "GREETING", Type: "string", Default: "hello $USER | #1"},
+		{
+			Name:                "WORKERS",
+			Type:                "int",
+			Default:             "4",
+			EnvironmentDefaults: map[env.Environment]string{env.ProductionEnvironment: "16"},
+			Description:         "Number of workers.\nKeep it close to the CPU count.",
+		},
+		{Name: "LEVEL", Type: "string", Default: "INFO", Rules: []string{"oneof=DEBUG,INFO", "notempty"}},
+		{Name: "DB_URL", Type: "string", Required: true, Description: "Database connection URL."},
+		{Name: "DB_PASSWORD", Type: "string", Required: true, Sensitive: true, Description: "Database password"},
+	}
+
+	variables, err := env.Describe(&generateTestConfig{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, variables) {
+		t.Errorf("Mismatch response.\nExpected '%+v'\ngot      '%+v'.", expected, variables)
+	}
+
+	var nested struct {
+		Cache *struct {
+			TTL time.Duration `env:"TTL" default:"1m"`
+		} `prefix:"CACHE_"`
+	}
+
+	expected = []env.Variable{{Name: "CACHE_TTL", Type: "time.Duration", Default: "1m"}}
+	if variables, err := env.Describe(nested); err != nil || !reflect.DeepEqual(expected, variables) {
+		t.Errorf("Expected nested struct pointers to be described, got '%+v' and '%v'.", variables, err)
+	}
+
+	if _, err := env.Describe("config"); errors.Kind(err) != errors.KindInvalidInput {
+		t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+	}
+}
+
+func TestGenerateDotEnvExample(t *testing.T) {
+	expected := `# HTTP server port. Type: int. Rules: min=1, max=65535.
+PORT=8080
+
+# Type: time.Duration.
+TIMEOUT=5s
+
+# Type: string.
+GREETING="hello \$USER | #1"
+
+# Number of workers.
+# Keep it close to the CPU count. Type: int. Defaults by environment: production: 16.
+WORKERS=4
+
+# Type: string. Rules: oneof=DEBUG,INFO, notempty.
+LEVEL=INFO
+
+# Database connection URL. Type: string. Required.
+DB_URL=
+
+# Database password. Type: string. Required.
+DB_PASSWORD=
+`
+
+	res, err := env.GenerateDotEnvExample(generateTestConfig{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if res != expected {
+		t.Errorf("Mismatch response.\nExpected:\n%s\ngot:\n%s", expected, res)
+	}
+
+	t.Run("should be readable as a .env file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env.example")
+		writeFile(t, path, res)
+
+		values, err := env.ReadDotEnv(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if values["GREETING"] != "hello $USER | #1" {
+			t.Errorf("Mismatch GREETING value, got '%s'.", values["GREETING"])
+		}
+
+		if values["WORKERS"] != "4" {
+			t.Errorf("Mismatch WORKERS value, got '%s'.", values["WORKERS"])
+		}
+	})
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	expected := "| Name | Type | Default | Required | Rules | Description |\n" +
+		"| ---- | ---- | ------- | -------- | ----- | ----------- |\n" +
+		"| `PORT` | `int` | `8080` | no | `min=1`, `max=65535` | HTTP server port |\n" +
+		"| `TIMEOUT` | `time.Duration` | `5s` | no |  |  |\n" +
+		"| `GREETING` | `string` | `hello $USER \\| #1` | no |  |  |\n" +
+		"| `WORKERS` | `int` | `4`, production: `16` | no |  | Number of workers. Keep it close to the CPU count. |\n" +
+		"| `LEVEL` | `string` | `INFO` | no | `oneof=DEBUG,INFO`, `notempty` |  |\n" +
+		"| `DB_URL` | `string` |  | yes |  | Database connection URL. |\n" +
+		"| `DB_PASSWORD` | `string` |  | yes |  | Database password |\n"
+
+	res, err := env.GenerateMarkdown(generateTestConfig{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if res != expected {
+		t.Errorf("Mismatch response.\nExpected:\n%s\ngot:\n%s", expected, res)
+	}
+}
+
+func TestGenerateKubernetes(t *testing.T) {
+	expected := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: go-kit
+data:
+  # HTTP server port
+  # Rules: min=1, max=65535.
+  PORT: "8080"
+  TIMEOUT: "5s"
+  GREETING: "hello $USER | #1"
+  # Number of workers.
+  # Keep it close to the CPU count.
+  # Defaults by environment: production: "16".
+  WORKERS: "4"
+  # Rules: oneof=DEBUG,INFO, notempty.
+  LEVEL: "INFO"
+  # Database connection URL.
+  DB_URL: ""
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: go-kit
+type: Opaque
+stringData:
+  # Database password
+  DB_PASSWORD: ""
+`
+
+	res, err := env.GenerateKubernetes(generateTestConfig{}, "go-kit")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if res != expected {
+		t.Errorf("Mismatch response.\nExpected:\n%s\ngot:\n%s", expected, res)
+	}
+}
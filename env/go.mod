@@ -1,3 +1,8 @@
 module github.com/trivelaapp/go-kit/env
 
 go 1.18
+
+require github.com/trivelaapp/go-kit/errors v0.2.0
+
+// The errors module is resolved from this repository, since its latest APIs are not released yet.
+replace github.com/trivelaapp/go-kit/errors => ../errors
@@ -0,0 +1,226 @@
+package env
+
+import (
+	e "errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// Load fills the struct pointed by cfg with environment variables, according to the tags of its fields:
+//   - env: the name of the environment variable;
+//   - default: the value used when the environment variable is empty;
+//   - default_<environment>: the value used when the environment variable is empty, in the given Environment (like default_production);
+//   - required: "true" if the environment variable can't be empty;
+//   - prefix: the prefix of the environment variables of a nested struct field;
+//   - sensitive: "true" if the value must be redacted from reports (see Report);
+//   - min, max, oneof, pattern, notempty and scheme: validation rules (see Rule).
+//
+// Nested struct fields (or pointers to structs) without an env tag are loaded recursively. Fields without tags are left untouched.
+//
+//	type Config struct {
+//		Port     int    `env:"PORT" default:"8080"`
+//		Database struct {
+//			URL string `env:"URL" required:"true"`
+//		} `prefix:"DB_"`
+//	}
+//
+// Instead of failing on the first issue, it returns an ErrInvalidConfiguration with all missing or invalid variables as violations.
+func Load(cfg any) error {
+	return defaultLoader.Load(cfg)
+}
+
+// MustLoad fills the struct pointed by cfg with environment variables, as Load does.
+// It panics if any environment variable is missing or invalid, listing all of them.
+func MustLoad(cfg any) {
+	defaultLoader.MustLoad(cfg)
+}
+
+// Load fills the struct pointed by cfg with the variables of the Loader Source, as the package Load function does.
+func (l *Loader) Load(cfg any) error {
+	target := reflect.ValueOf(cfg)
+	if target.Kind() != reflect.Pointer || target.Elem().Kind() != reflect.Struct {
+		return errors.New("configuration must be a pointer to a struct, got %T", cfg).WithKind(errors.KindInvalidInput)
+	}
+
+	environment := &lazyEnvironment{loader: l}
+	violations := l.loadStruct(target.Elem(), "", environment)
+
+	// Unknown environments are only reported when some field has per environment defaults.
+	if environment.resolved && environment.err != nil {
+		violations = append(violations, errors.FieldViolation{
+			Field:   EnvironmentVariable,
+			Rule:    "environment",
+			Message: environment.err.Error(),
+		})
+	}
+
+	if len(violations) > 0 {
+		return ErrInvalidConfiguration.WithViolations(violations...)
+	}
+
+	return nil
+}
+
+// MustLoad fills the struct pointed by cfg with the variables of the Loader Source, as Load does.
+// It panics if any variable is missing or invalid, listing all of them.
+func (l *Loader) MustLoad(cfg any) {
+	if err := l.Load(cfg); err != nil {
+		msgs := []string{}
+		for _, violation := range errors.Violations(err) {
+			msgs = append(msgs, violation.Message)
+		}
+
+		if len(msgs) == 0 {
+			panic(err.Error())
+		}
+
+		panic(fmt.Sprintf("%s: %s", err.Error(), strings.Join(msgs, "; ")))
+	}
+}
+
+func (l *Loader) loadStruct(target reflect.Value, prefix string, environment *lazyEnvironment) []errors.FieldViolation {
+	violations := []errors.FieldViolation{}
+
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			if nested, ok := nestedStruct(target.Field(i)); ok {
+				violations = append(violations, l.loadStruct(nested, prefix+field.Tag.Get("prefix"), environment)...)
+			}
+			continue
+		}
+
+		violations = append(violations, l.loadField(target.Field(i), field, prefix+name, environment)...)
+	}
+
+	return violations
+}
+
+// nestedStruct returns the struct held by a nested struct field, which can also be a pointer to a struct.
+// Nil pointers are set to a new struct, so it can be loaded, unless it declares no variables.
+func nestedStruct(target reflect.Value) (reflect.Value, bool) {
+	typ, ok := nestedStructType(target.Type())
+	if !ok || target.Kind() != reflect.Pointer {
+		return target, ok
+	}
+
+	if target.IsNil() {
+		if len(describeStruct(typ, "")) == 0 {
+			return reflect.Value{}, false
+		}
+		target.Set(reflect.New(typ))
+	}
+
+	return target.Elem(), true
+}
+
+// nestedStructType returns the struct type of a nested struct field, which can also be a pointer to a struct.
+func nestedStructType(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ, typ.Kind() == reflect.Struct
+}
+
+func (l *Loader) loadField(target reflect.Value, field reflect.StructField, name string, environment *lazyEnvironment) []errors.FieldViolation {
+	value, _, err := l.resolve(name)
+	if err != nil {
+		return []errors.FieldViolation{{
+			Field:   name,
+			Rule:    "file",
+			Message: err.Error(),
+		}}
+	}
+
+	if field.Tag.Get("sensitive") == "true" {
+		l.MarkSensitive(name)
+	}
+
+	if value == "" {
+		value = defaultValue(field, environment)
+		if value != "" {
+			l.trackDefault(name, value)
+		}
+	}
+
+	if value == "" && field.Tag.Get("required") == "true" {
+		return []errors.FieldViolation{{
+			Field:   name,
+			Rule:    "required",
+			Message: fmt.Sprintf("%s can't be empty", name),
+		}}
+	}
+
+	if value != "" {
+		if err := parseValue(value, target); err != nil {
+			msg := fmt.Sprintf("%s must contain a valid %s value", name, field.Type)
+			if e.Is(err, errUnsupportedType) {
+				msg = fmt.Sprintf("%s can't be loaded into a %s field", name, field.Type)
+			}
+
+			return []errors.FieldViolation{{
+				Field:   name,
+				Rule:    "type",
+				Message: msg,
+			}}
+		}
+	}
+
+	rules, err := rulesFromTags(field)
+	if err != nil {
+		return []errors.FieldViolation{{
+			Field:   name,
+			Rule:    "tag",
+			Message: fmt.Sprintf("%s has an %s", name, err),
+		}}
+	}
+
+	return validateRules(name, value, field.Type, rules)
+}
+
+// lazyEnvironment resolves the Environment of a Loader once, and only when it's needed.
+type lazyEnvironment struct {
+	loader      *Loader
+	resolved    bool
+	environment Environment
+	err         error
+}
+
+func (le *lazyEnvironment) get() (Environment, error) {
+	if !le.resolved {
+		le.environment, le.err = le.loader.Environment()
+		le.resolved = true
+	}
+	return le.environment, le.err
+}
+
+// defaultValue returns the default value of the field in the current Environment, falling back to its default tag.
+// The Environment is only resolved for fields with default_<environment> tags.
+func defaultValue(field reflect.StructField, environment *lazyEnvironment) string {
+	if hasEnvironmentDefaults(field) {
+		if current, err := environment.get(); err == nil {
+			if value, ok := field.Tag.Lookup("default_" + current.String()); ok {
+				return value
+			}
+		}
+	}
+	return field.Tag.Get("default")
+}
+
+// hasEnvironmentDefaults checks if the field has any default_<environment> tag.
+func hasEnvironmentDefaults(field reflect.StructField) bool {
+	for _, environment := range environments {
+		if _, ok := field.Tag.Lookup("default_" + environment.String()); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package env_test
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+type loadTestConfig struct {
+	Name    string  `env:"LOAD_NAME" required:"true"`
+	Port    int     `env:"LOAD_PORT" default:"8080"`
+	Ratio   float64 `env:"LOAD_RATIO"`
+	Debug   bool    `env:"LOAD_DEBUG"`
+	Ignored string
+	DB      struct {
+		URL      string `env:"URL" required:"true"`
+		MaxConns uint8  `env:"MAX_CONNS" default:"10"`
+	} `prefix:"LOAD_DB_"`
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should load the configuration successfully", func(t *testing.T) {
+		t.Setenv("LOAD_NAME", "go-kit")
+		t.Setenv("LOAD_RATIO", "0.5")
+		t.Setenv("LOAD_DEBUG", "true")
+		t.Setenv("LOAD_DB_URL", "postgres://localhost")
+
+		var cfg loadTestConfig
+		if err := env.Load(&cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := loadTestConfig{Name: "go-kit", Port: 8080, Ratio: 0.5, Debug: true}
+		expected.DB.URL = "postgres://localhost"
+		expected.DB.MaxConns = 10
+
+		if !reflect.DeepEqual(expected, cfg) {
+			t.Errorf("Mismatch response. Expected '%+v', got '%+v'.", expected, cfg)
+		}
+	})
+
+	t.Run("should report all missing and invalid variables together", func(t *testing.T) {
+		t.Setenv("LOAD_PORT", "http")
+		t.Setenv("LOAD_DB_MAX_CONNS", "1000")
+
+		var cfg loadTestConfig
+		err := env.Load(&cfg)
+
+		if errors.Code(err) != errors.Code(env.ErrInvalidConfiguration) {
+			t.Errorf("Wrong error code, got: %s", errors.Code(err))
+		}
+
+		expected := []errors.FieldViolation{
+			{Field: "LOAD_NAME", Rule: "required", Message: "LOAD_NAME can't be empty"},
+			{Field: "LOAD_PORT", Rule: "type", Message: "LOAD_PORT must contain a valid int value"},
+			{Field: "LOAD_DB_URL", Rule: "required", Message: "LOAD_DB_URL can't be empty"},
+			{Field: "LOAD_DB_MAX_CONNS", Rule: "type", Message: "LOAD_DB_MAX_CONNS must contain a valid uint8 value"},
+		}
+		if violations := errors.Violations(err); !reflect.DeepEqual(expected, violations) {
+			t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", expected, violations)
+		}
+	})
+
+	t.Run("should fail when the configuration is not a pointer to a struct", func(t *testing.T) {
+		if err := env.Load(loadTestConfig{}); errors.Kind(err) != errors.KindInvalidInput {
+			t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+		}
+	})
+}
+
+func TestLoadRichTypes(t *testing.T) {
+	t.Setenv("LOAD_TIMEOUT", "5s")
+	t.Setenv("LOAD_HOSTS", "a.com,b.com")
+	t.Setenv("LOAD_WEIGHTS", "a=1,b=2")
+	t.Setenv("LOAD_ENDPOINT", "https://trivela.com.br")
+	t.Setenv("LOAD_MAX_BODY", "1MiB")
+	t.Setenv("LOAD_IP", "10.0.0.1")
+	t.Setenv("LOAD_RETRIES", "3")
+
+	var cfg struct {
+		Timeout  time.Duration  `env:"LOAD_TIMEOUT"`
+		Hosts    []string       `env:"LOAD_HOSTS"`
+		Weights  map[string]int `env:"LOAD_WEIGHTS"`
+		Endpoint *url.URL       `env:"LOAD_ENDPOINT"`
+		MaxBody  env.ByteSize   `env:"LOAD_MAX_BODY"`
+		IP       net.IP         `env:"LOAD_IP"`
+		Retries  *int           `env:"LOAD_RETRIES"`
+		Channel  chan int       `env:"LOAD_TIMEOUT"`
+	}
+
+	err := env.Load(&cfg)
+
+	expected := []errors.FieldViolation{
+		{Field: "LOAD_TIMEOUT", Rule: "type", Message: "LOAD_TIMEOUT can't be loaded into a chan int field"},
+	}
+	if violations := errors.Violations(err); !reflect.DeepEqual(expected, violations) {
+		t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", expected, violations)
+	}
+
+	if cfg.Timeout != 5*time.Second ||
+		!reflect.DeepEqual([]string{"a.com", "b.com"}, cfg.Hosts) ||
+		!reflect.DeepEqual(map[string]int{"a": 1, "b": 2}, cfg.Weights) ||
+		cfg.Endpoint.String() != "https://trivela.com.br" ||
+		cfg.MaxBody != env.Mebibyte ||
+		cfg.IP.String() != "10.0.0.1" ||
+		*cfg.Retries != 3 {
+		t.Errorf("Mismatch response, got '%+v'.", cfg)
+	}
+}
+
+func TestLoadNestedPointers(t *testing.T) {
+	t.Setenv("LOAD_CACHE_TTL", "1m")
+
+	var cfg struct {
+		Cache *struct {
+			TTL  time.Duration `env:"TTL"`
+			Size int           `env:"SIZE" default:"100"`
+		} `prefix:"LOAD_CACHE_"`
+		Client *http.Client
+	}
+
+	if err := env.Load(&cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Cache == nil || cfg.Cache.TTL != time.Minute || cfg.Cache.Size != 100 {
+		t.Errorf("Mismatch nested struct response, got '%+v'.", cfg.Cache)
+	}
+
+	if cfg.Client != nil {
+		t.Errorf("Expected nested structs without variables to be left untouched, got '%+v'.", cfg.Client)
+	}
+}
+
+func TestMustLoad(t *testing.T) {
+	defer func() {
+		expectedPanicMsg := "invalid configuration: LOAD_NAME can't be empty; LOAD_DB_URL can't be empty"
+		if e := recover(); e != expectedPanicMsg {
+			t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+		}
+	}()
+
+	var cfg loadTestConfig
+	env.MustLoad(&cfg)
+}
@@ -0,0 +1,372 @@
+package env
+
+import (
+	"encoding"
+	e "errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// defaultLoader backs the package level functions, reading the process environment.
+var defaultLoader = NewLoader(LoaderParams{})
+
+// LoaderParams encapsulates the parameters of a Loader.
+type LoaderParams struct {
+	// Source provides the variables values. Defaults to the process environment (see NewOSSource).
+	Source Source
+	// SecretsDir is the directory of secret files (like Docker and Kubernetes mounted secrets), named after the variables they hold.
+	// It's looked up when neither the variable nor its _FILE variant are set.
+	SecretsDir string
+}
+
+// Loader extracts typed values from the variables of a Source.
+// It exposes the same functions of the package, which are shortcuts for a Loader of the process environment.
+type Loader struct {
+	source Source
+
+	mu                  sync.RWMutex
+	secretsDir          string
+	sensitive           map[string]bool
+	accesses            map[string]access
+	environmentDefaults map[Environment]map[string]string
+}
+
+// NewLoader creates a new Loader with the given parameters.
+func NewLoader(params LoaderParams) *Loader {
+	source := params.Source
+	if source == nil {
+		source = NewOSSource()
+	}
+
+	return &Loader{
+		source:              source,
+		secretsDir:          params.SecretsDir,
+		sensitive:           map[string]bool{},
+		accesses:            map[string]access{},
+		environmentDefaults: map[Environment]map[string]string{},
+	}
+}
+
+// lookup returns the value of the given variable, falling back to its default in the current Environment (see SetEnvironmentDefaults).
+// Missing variables, unreadable secret files and unknown environments are handled as empty values.
+func (l *Loader) lookup(name string) string {
+	value, _, _ := l.resolve(name)
+	if value == "" {
+		value, _ = l.environmentDefault(name)
+	}
+	return value
+}
+
+// mustLookup returns the value of the given variable, as lookup does.
+// It panics if the value can't be read from its secret file, or if the current Environment is unknown.
+func (l *Loader) mustLookup(name string) string {
+	value, _, err := l.resolve(name)
+	if err != nil {
+		panic(fmt.Sprintf("%s: %s", err.Error(), errors.RootError(err)))
+	}
+
+	if value == "" {
+		if value, err = l.environmentDefault(name); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return value
+}
+
+// GetString extracts a String value from the given variable.
+func (l *Loader) GetString(name string, defaultValue ...string) string {
+	value := l.lookup(name)
+	if value == "" && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetString extracts a String value from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetString(name string, rules ...Rule) string {
+	value := l.mustLookup(name)
+	if value == "" {
+		panic(fmt.Sprintf("%s can't be empty", name))
+	}
+	l.mustValidate(name, value, stringType, rules)
+	return value
+}
+
+// GetInt extracts an Int value from the given variable.
+func (l *Loader) GetInt(name string, defaultValue ...int) int {
+	value, err := strconv.Atoi(l.lookup(name))
+	if err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetInt extracts an Int value from the given variable.
+// It exits the application if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetInt(name string, rules ...Rule) int {
+	raw := l.mustLookup(name)
+	var value int
+	mustParse(name, raw, &value, "an int value")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetFloat extracts a Float value from the given variable.
+func (l *Loader) GetFloat(name string, defaultValue ...float64) float64 {
+	value, err := strconv.ParseFloat(l.lookup(name), 64)
+	if err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetFloat extracts a Float value from the given variable.
+// It exits the application if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetFloat(name string, rules ...Rule) float64 {
+	raw := l.mustLookup(name)
+	var value float64
+	mustParse(name, raw, &value, "a float value")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetBool extracts a Bool value from the given variable.
+func (l *Loader) GetBool(name string, defaultValue ...bool) bool {
+	value, err := strconv.ParseBool(l.lookup(name))
+	if err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetBool extracts a Bool value from the given variable.
+// It exits the application if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetBool(name string, rules ...Rule) bool {
+	raw := l.mustLookup(name)
+	var value bool
+	mustParse(name, raw, &value, "a boolean value")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetDuration extracts a Duration value (like "1m30s") from the given variable.
+func (l *Loader) GetDuration(name string, defaultValue ...time.Duration) time.Duration {
+	var value time.Duration
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetDuration extracts a Duration value (like "1m30s") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetDuration(name string, rules ...Rule) time.Duration {
+	raw := l.mustLookup(name)
+	var value time.Duration
+	mustParse(name, raw, &value, "a duration value")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetStringSlice extracts a list of String values (like "a,b,c") from the given variable.
+func (l *Loader) GetStringSlice(name string, defaultValue ...[]string) []string {
+	var value []string
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetStringSlice extracts a list of String values (like "a,b,c") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetStringSlice(name string, rules ...Rule) []string {
+	raw := l.mustLookup(name)
+	var value []string
+	mustParse(name, raw, &value, "a list of string values")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetIntSlice extracts a list of Int values (like "1,2,3") from the given variable.
+func (l *Loader) GetIntSlice(name string, defaultValue ...[]int) []int {
+	var value []int
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetIntSlice extracts a list of Int values (like "1,2,3") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetIntSlice(name string, rules ...Rule) []int {
+	raw := l.mustLookup(name)
+	var value []int
+	mustParse(name, raw, &value, "a list of int values")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetFloatSlice extracts a list of Float values (like "1.5,2.5") from the given variable.
+func (l *Loader) GetFloatSlice(name string, defaultValue ...[]float64) []float64 {
+	var value []float64
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetFloatSlice extracts a list of Float values (like "1.5,2.5") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetFloatSlice(name string, rules ...Rule) []float64 {
+	raw := l.mustLookup(name)
+	var value []float64
+	mustParse(name, raw, &value, "a list of float values")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetBoolSlice extracts a list of Bool values (like "true,false") from the given variable.
+func (l *Loader) GetBoolSlice(name string, defaultValue ...[]bool) []bool {
+	var value []bool
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetBoolSlice extracts a list of Bool values (like "true,false") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetBoolSlice(name string, rules ...Rule) []bool {
+	raw := l.mustLookup(name)
+	var value []bool
+	mustParse(name, raw, &value, "a list of boolean values")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetDurationSlice extracts a list of Duration values (like "1s,5s,1m") from the given variable.
+func (l *Loader) GetDurationSlice(name string, defaultValue ...[]time.Duration) []time.Duration {
+	var value []time.Duration
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetDurationSlice extracts a list of Duration values (like "1s,5s,1m") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetDurationSlice(name string, rules ...Rule) []time.Duration {
+	raw := l.mustLookup(name)
+	var value []time.Duration
+	mustParse(name, raw, &value, "a list of duration values")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetStringMap extracts a map of String values (like "a=1,b=2") from the given variable.
+func (l *Loader) GetStringMap(name string, defaultValue ...map[string]string) map[string]string {
+	var value map[string]string
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetStringMap extracts a map of String values (like "a=1,b=2") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetStringMap(name string, rules ...Rule) map[string]string {
+	raw := l.mustLookup(name)
+	var value map[string]string
+	mustParse(name, raw, &value, "a map of key=value pairs")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetURL extracts an absolute URL value from the given variable.
+func (l *Loader) GetURL(name string, defaultValue ...*url.URL) *url.URL {
+	var value *url.URL
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetURL extracts an absolute URL value from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetURL(name string, rules ...Rule) *url.URL {
+	raw := l.mustLookup(name)
+	var value *url.URL
+	mustParse(name, raw, &value, "an absolute URL value")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetByteSize extracts a ByteSize value (like "10MB") from the given variable.
+func (l *Loader) GetByteSize(name string, defaultValue ...ByteSize) ByteSize {
+	var value ByteSize
+	if err := parse(l.lookup(name), &value); err != nil && len(defaultValue) > 0 {
+		value = defaultValue[0]
+		l.trackDefault(name, fmt.Sprint(value))
+	}
+	return value
+}
+
+// MustGetByteSize extracts a ByteSize value (like "10MB") from the given variable.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetByteSize(name string, rules ...Rule) ByteSize {
+	raw := l.mustLookup(name)
+	var value ByteSize
+	mustParse(name, raw, &value, "a byte size value")
+	l.mustValidate(name, raw, reflect.TypeOf(value), rules)
+	return value
+}
+
+// GetText extracts a value from the given variable into target, using its UnmarshalText method.
+// If it fails, the default value is unmarshaled instead.
+func (l *Loader) GetText(name string, target encoding.TextUnmarshaler, defaultValue ...string) {
+	if err := parse(l.lookup(name), target); err != nil && len(defaultValue) > 0 {
+		_ = target.UnmarshalText([]byte(defaultValue[0]))
+		l.trackDefault(name, defaultValue[0])
+	}
+}
+
+// MustGetText extracts a value from the given variable into target, using its UnmarshalText method.
+// It panics if not present, or if any of the given rules is broken.
+func (l *Loader) MustGetText(name string, target encoding.TextUnmarshaler, rules ...Rule) {
+	raw := l.mustLookup(name)
+	mustParse(name, raw, target, fmt.Sprintf("a valid %s value", reflect.TypeOf(target).Elem()))
+	l.mustValidate(name, raw, reflect.TypeOf(target), rules)
+}
+
+// mustParse parses the raw value of the given variable into the value pointed by target.
+// It panics if it fails, stating that the variable must contain the described value, followed by the parse error.
+func mustParse(name, raw string, target any, description string) {
+	err := parse(raw, target)
+	switch {
+	case err == nil:
+	case e.Is(err, errEmptyValue):
+		panic(fmt.Sprintf("%s must contain %s", name, description))
+	default:
+		panic(fmt.Sprintf("%s must contain %s: %s", name, description, err))
+	}
+}
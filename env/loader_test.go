@@ -0,0 +1,65 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trivelaapp/go-kit/env"
+)
+
+func TestLoader(t *testing.T) {
+	loader := env.NewLoader(env.LoaderParams{
+		Source: env.MapSource{
+			"LOADER_NAME":    "go-kit",
+			"LOADER_PORT":    "8080",
+			"LOADER_TIMEOUT": "5s",
+			"LOADER_HOSTS":   "a.com,b.com",
+		},
+	})
+
+	t.Run("should extract values from its source", func(t *testing.T) {
+		t.Parallel()
+
+		if res := loader.MustGetString("LOADER_NAME"); res != "go-kit" {
+			t.Errorf("Mismatch string response, got '%s'.", res)
+		}
+
+		if res := loader.MustGetInt("LOADER_PORT"); res != 8080 {
+			t.Errorf("Mismatch int response, got '%d'.", res)
+		}
+
+		if res := loader.GetDuration("LOADER_MISSING", time.Second); res != time.Second {
+			t.Errorf("Mismatch duration response, got '%s'.", res)
+		}
+	})
+
+	t.Run("should load structs from its source", func(t *testing.T) {
+		t.Parallel()
+
+		var cfg struct {
+			Timeout time.Duration `env:"LOADER_TIMEOUT"`
+			Hosts   []string      `env:"LOADER_HOSTS" required:"true"`
+		}
+
+		if err := loader.Load(&cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Timeout != 5*time.Second || len(cfg.Hosts) != 2 {
+			t.Errorf("Mismatch response, got '%+v'.", cfg)
+		}
+	})
+
+	t.Run("should not read the process environment", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			expectedPanicMsg := "PATH can't be empty"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+			}
+		}()
+
+		loader.MustGetString("PATH")
+	})
+}
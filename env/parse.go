@@ -0,0 +1,153 @@
+package env
+
+import (
+	"encoding"
+	e "errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// errUnsupportedType indicates that values of a given type can't be parsed from environment variables.
+	errUnsupportedType = e.New("unsupported type")
+
+	// errEmptyValue indicates that the environment variable is empty.
+	errEmptyValue = e.New("empty value")
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringType          = reflect.TypeOf("")
+	durationType        = reflect.TypeOf(time.Duration(0))
+	byteSizeType        = reflect.TypeOf(ByteSize(0))
+	urlType             = reflect.TypeOf(url.URL{})
+)
+
+// parse parses the raw value of an environment variable into the value pointed by target.
+// Empty values are rejected, since they are handled as missing variables.
+func parse(raw string, target any) error {
+	if raw == "" {
+		return errEmptyValue
+	}
+	return parseValue(raw, reflect.ValueOf(target).Elem())
+}
+
+// parseValue parses the raw value of an environment variable into the given target, according to its type.
+// Besides strings, bools and numbers, it supports:
+//   - encoding.TextUnmarshaler implementations;
+//   - time.Duration, like "1m30s";
+//   - url.URL, which must be absolute;
+//   - pointers to any supported type;
+//   - comma-separated slices of any supported type, like "a,b,c";
+//   - comma-separated maps of any supported key and value types, like "a=1,b=2".
+func parseValue(raw string, target reflect.Value) error {
+	if target.CanAddr() && target.Addr().Type().Implements(textUnmarshalerType) {
+		return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch target.Type() {
+	case durationType:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(value))
+		return nil
+	case urlType:
+		value, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+		if !value.IsAbs() {
+			return fmt.Errorf("url '%s' must be absolute", raw)
+		}
+		target.Set(reflect.ValueOf(*value))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		target.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(value)
+	case reflect.Pointer:
+		value := reflect.New(target.Type().Elem())
+		if err := parseValue(raw, value.Elem()); err != nil {
+			return err
+		}
+		target.Set(value)
+	case reflect.Slice:
+		return parseSlice(raw, target)
+	case reflect.Map:
+		return parseMap(raw, target)
+	default:
+		return errUnsupportedType
+	}
+
+	return nil
+}
+
+func parseSlice(raw string, target reflect.Value) error {
+	items := strings.Split(raw, ",")
+
+	slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := parseValue(strings.TrimSpace(item), slice.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	target.Set(slice)
+	return nil
+}
+
+func parseMap(raw string, target reflect.Value) error {
+	m := reflect.MakeMap(target.Type())
+	for _, item := range strings.Split(raw, ",") {
+		pair := strings.SplitN(item, "=", 2)
+		if len(pair) != 2 {
+			return fmt.Errorf("map item '%s' must be a key=value pair", item)
+		}
+
+		key := reflect.New(target.Type().Key()).Elem()
+		if err := parseValue(strings.TrimSpace(pair[0]), key); err != nil {
+			return err
+		}
+
+		value := reflect.New(target.Type().Elem()).Elem()
+		if err := parseValue(strings.TrimSpace(pair[1]), value); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(key, value)
+	}
+
+	target.Set(m)
+	return nil
+}
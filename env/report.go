@@ -0,0 +1,121 @@
+package env
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// RedactedValue replaces the values of sensitive variables in reports.
+const RedactedValue = "[REDACTED]"
+
+// Origin indicates where the value of a variable came from.
+type Origin string
+
+const (
+	// OriginEnv indicates that the value came from the variable itself, in the process environment or in the Loader Source.
+	OriginEnv Origin = "env"
+
+	// OriginFile indicates that the value was read from a secret file (see FileSuffix).
+	OriginFile Origin = "file"
+
+	// OriginDefault indicates that the default value was used.
+	OriginDefault Origin = "default"
+
+	// OriginUnset indicates that the variable was accessed, but it had no value.
+	OriginUnset Origin = "unset"
+)
+
+// access is the last access of a variable.
+type access struct {
+	value  string
+	origin Origin
+}
+
+// ReportEntry describes a variable accessed by a Loader.
+type ReportEntry struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Origin    Origin `json:"origin"`
+	Sensitive bool   `json:"sensitive"`
+}
+
+// Report lists the variables accessed by a Loader, sorted by name.
+type Report []ReportEntry
+
+// MarkSensitive marks the given environment variables as sensitive, so their values are redacted from reports.
+// Values read from secret files are always sensitive.
+func MarkSensitive(names ...string) {
+	defaultLoader.MarkSensitive(names...)
+}
+
+// MarkSensitive marks the given variables as sensitive, so their values are redacted from reports.
+// Values read from secret files are always sensitive.
+func (l *Loader) MarkSensitive(names ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, name := range names {
+		l.sensitive[name] = true
+	}
+}
+
+// GetReport returns the environment variables accessed by the package functions, with their origin and sensitive values redacted.
+// It's supposed to be logged once the application starts (e.g. with log.Logger.JSON), so we know which configuration it's running with.
+func GetReport() Report {
+	return defaultLoader.GetReport()
+}
+
+// GetReport returns the variables accessed by the Loader, with their origin and sensitive values redacted.
+func (l *Loader) GetReport() Report {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	report := Report{}
+	for name, access := range l.accesses {
+		entry := ReportEntry{
+			Name:      name,
+			Value:     access.value,
+			Origin:    access.origin,
+			Sensitive: l.sensitive[name],
+		}
+
+		if entry.Sensitive && entry.Value != "" {
+			entry.Value = RedactedValue
+		}
+
+		report = append(report, entry)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Name < report[j].Name
+	})
+
+	return report
+}
+
+// ReportHandler serves the report of the environment variables accessed by the package functions as JSON.
+// It's supposed to be exposed by admin endpoints only.
+func ReportHandler() http.Handler {
+	return defaultLoader.ReportHandler()
+}
+
+// ReportHandler serves the report of the variables accessed by the Loader as JSON.
+// It's supposed to be exposed by admin endpoints only.
+func (l *Loader) ReportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(l.GetReport())
+	})
+}
+
+func (l *Loader) track(name, value string, origin Origin) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.accesses[name] = access{value: value, origin: origin}
+}
+
+func (l *Loader) trackDefault(name, value string) {
+	l.track(name, value, OriginDefault)
+}
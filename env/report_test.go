@@ -0,0 +1,70 @@
+package env_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/trivelaapp/go-kit/env"
+)
+
+func TestReport(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "password"), "secret\n")
+
+	loader := env.NewLoader(env.LoaderParams{
+		Source: env.MapSource{
+			"REPORT_PORT":          "8080",
+			"REPORT_API_KEY":       "api-key",
+			"REPORT_TOKEN":         "token",
+			"REPORT_PASSWORD_FILE": filepath.Join(dir, "password"),
+		},
+	})
+	loader.MarkSensitive("REPORT_API_KEY")
+
+	loader.GetInt("REPORT_PORT", 80)
+	loader.GetInt("REPORT_WORKERS", 4)
+	loader.GetString("REPORT_API_KEY")
+	loader.GetString("REPORT_MISSING")
+
+	var cfg struct {
+		Password string `env:"REPORT_PASSWORD"`
+		Token    string `env:"REPORT_TOKEN" sensitive:"true"`
+		Region   string `env:"REPORT_REGION" default:"us-east-1"`
+	}
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := env.Report{
+		{Name: "REPORT_API_KEY", Value: env.RedactedValue, Origin: env.OriginEnv, Sensitive: true},
+		{Name: "REPORT_MISSING", Value: "", Origin: env.OriginUnset},
+		{Name: "REPORT_PASSWORD", Value: env.RedactedValue, Origin: env.OriginFile, Sensitive: true},
+		{Name: "REPORT_PORT", Value: "8080", Origin: env.OriginEnv},
+		{Name: "REPORT_REGION", Value: "us-east-1", Origin: env.OriginDefault},
+		{Name: "REPORT_TOKEN", Value: env.RedactedValue, Origin: env.OriginEnv, Sensitive: true},
+		{Name: "REPORT_WORKERS", Value: "4", Origin: env.OriginDefault},
+	}
+
+	t.Run("should report all accessed variables", func(t *testing.T) {
+		if report := loader.GetReport(); !reflect.DeepEqual(expected, report) {
+			t.Errorf("Mismatch report.\nExpected '%+v'\ngot      '%+v'.", expected, report)
+		}
+	})
+
+	t.Run("should serve the report as JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		loader.ReportHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/config", nil))
+
+		var report env.Report
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(expected, report) {
+			t.Errorf("Mismatch report.\nExpected '%+v'\ngot      '%+v'.", expected, report)
+		}
+	})
+}
@@ -0,0 +1,369 @@
+package env
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// Rule is a constraint on the value of a variable, like Min(1) or OneOf("DEBUG", "INFO").
+// Rules are checked against empty values too, but only NotEmpty breaks on them.
+type Rule struct {
+	name  string
+	check func(raw string, number number) (string, bool)
+}
+
+// number converts the values of a variable into numbers comparable by Min and Max, according to its type.
+type number struct {
+	parse  func(raw string) (float64, error)
+	format func(value float64) string
+}
+
+var (
+	floatNumber = number{
+		parse: func(raw string) (float64, error) {
+			return strconv.ParseFloat(raw, 64)
+		},
+		format: func(value float64) string {
+			return fmt.Sprint(value)
+		},
+	}
+
+	durationNumber = number{
+		parse: func(raw string) (float64, error) {
+			value, err := time.ParseDuration(raw)
+			return float64(value), err
+		},
+		format: func(value float64) string {
+			return time.Duration(value).String()
+		},
+	}
+
+	byteSizeNumber = number{
+		parse: func(raw string) (float64, error) {
+			value, err := ParseByteSize(raw)
+			return float64(value), err
+		},
+		format: func(value float64) string {
+			return strconv.FormatFloat(value, 'f', -1, 64) + " bytes"
+		},
+	}
+)
+
+// numberOf returns how the values of the given type are compared by Min and Max.
+func numberOf(typ reflect.Type) number {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ {
+	case durationType:
+		return durationNumber
+	case byteSizeType:
+		return byteSizeNumber
+	default:
+		return floatNumber
+	}
+}
+
+// Min requires the variable to contain a number greater than or equal to min.
+// Durations are compared in nanoseconds and ByteSizes in bytes, like Min(float64(time.Second)).
+func Min(min float64) Rule {
+	return Rule{name: "min", check: func(raw string, number number) (string, bool) {
+		if raw == "" {
+			return "", true
+		}
+
+		value, err := number.parse(raw)
+		return fmt.Sprintf("must be greater than or equal to %s", number.format(min)), err == nil && value >= min
+	}}
+}
+
+// Max requires the variable to contain a number less than or equal to max.
+// Durations are compared in nanoseconds and ByteSizes in bytes, like Max(float64(10 * env.Megabyte)).
+func Max(max float64) Rule {
+	return Rule{name: "max", check: func(raw string, number number) (string, bool) {
+		if raw == "" {
+			return "", true
+		}
+
+		value, err := number.parse(raw)
+		return fmt.Sprintf("must be less than or equal to %s", number.format(max)), err == nil && value <= max
+	}}
+}
+
+// OneOf requires the variable to contain one of the given values.
+func OneOf(values ...string) Rule {
+	return Rule{name: "oneof", check: func(raw string, _ number) (string, bool) {
+		if raw == "" {
+			return "", true
+		}
+
+		for _, value := range values {
+			if raw == value {
+				return "", true
+			}
+		}
+		return fmt.Sprintf("must be one of: %s", strings.Join(values, ", ")), false
+	}}
+}
+
+// Pattern requires the variable to match the given regular expression. It panics if the expression can't be compiled.
+func Pattern(expr string) Rule {
+	return patternRule(regexp.MustCompile(expr))
+}
+
+func patternRule(re *regexp.Regexp) Rule {
+	return Rule{name: "pattern", check: func(raw string, _ number) (string, bool) {
+		if raw == "" {
+			return "", true
+		}
+
+		return fmt.Sprintf("must match the pattern %s", re), re.MatchString(raw)
+	}}
+}
+
+// NotEmpty requires the variable to contain something other than white spaces.
+func NotEmpty() Rule {
+	return Rule{name: "notempty", check: func(raw string, _ number) (string, bool) {
+		return "can't be blank", strings.TrimSpace(raw) != ""
+	}}
+}
+
+// Scheme requires the variable to contain a URL with one of the given schemes.
+func Scheme(schemes ...string) Rule {
+	return Rule{name: "scheme", check: func(raw string, _ number) (string, bool) {
+		if raw == "" {
+			return "", true
+		}
+
+		msg := fmt.Sprintf("must be a URL with one of the schemes: %s", strings.Join(schemes, ", "))
+
+		value, err := url.Parse(raw)
+		if err != nil {
+			return msg, false
+		}
+
+		for _, scheme := range schemes {
+			if strings.EqualFold(value.Scheme, scheme) {
+				return "", true
+			}
+		}
+		return msg, false
+	}}
+}
+
+// Validate checks the value of the given environment variable against the given rules.
+// It returns an ErrInvalidConfiguration with all broken rules as violations. Empty variables only break NotEmpty.
+// The value is checked as a whole, and Min and Max compare it as a float (see ValidateDuration and ValidateByteSize).
+func Validate(name string, rules ...Rule) error {
+	return defaultLoader.Validate(name, rules...)
+}
+
+// ValidateDuration checks the Duration value (like "1m30s") of the given environment variable against the given rules,
+// as Validate does. Min and Max compare it in nanoseconds, like Min(float64(time.Second)).
+func ValidateDuration(name string, rules ...Rule) error {
+	return defaultLoader.ValidateDuration(name, rules...)
+}
+
+// ValidateByteSize checks the ByteSize value (like "10MB") of the given environment variable against the given rules,
+// as Validate does. Min and Max compare it in bytes, like Max(float64(10 * env.Megabyte)).
+func ValidateByteSize(name string, rules ...Rule) error {
+	return defaultLoader.ValidateByteSize(name, rules...)
+}
+
+// Validate checks the value of the given variable against the given rules, as the package Validate function does.
+func (l *Loader) Validate(name string, rules ...Rule) error {
+	return l.validate(name, stringType, rules)
+}
+
+// ValidateDuration checks the Duration value of the given variable against the given rules, as the package ValidateDuration function does.
+func (l *Loader) ValidateDuration(name string, rules ...Rule) error {
+	return l.validate(name, durationType, rules)
+}
+
+// ValidateByteSize checks the ByteSize value of the given variable against the given rules, as the package ValidateByteSize function does.
+func (l *Loader) ValidateByteSize(name string, rules ...Rule) error {
+	return l.validate(name, byteSizeType, rules)
+}
+
+// validate checks the value of the given variable against the given rules, parsing it into the given type as Load does.
+// Values that can't be parsed are reported as a type violation instead.
+func (l *Loader) validate(name string, typ reflect.Type, rules []Rule) error {
+	raw := l.lookup(name)
+	if raw != "" {
+		if err := parseValue(raw, reflect.New(typ).Elem()); err != nil {
+			return ErrInvalidConfiguration.WithViolations(errors.FieldViolation{
+				Field:   name,
+				Rule:    "type",
+				Message: fmt.Sprintf("%s must contain a valid %s value", name, typ),
+			})
+		}
+	}
+
+	violations := validateRules(name, raw, typ, rules)
+	if len(violations) > 0 {
+		return ErrInvalidConfiguration.WithViolations(violations...)
+	}
+	return nil
+}
+
+// mustValidate panics listing all rules broken by the given raw value of the given type.
+func (l *Loader) mustValidate(name, raw string, typ reflect.Type, rules []Rule) {
+	msgs := []string{}
+	for _, violation := range validateRules(name, raw, typ, rules) {
+		msgs = append(msgs, violation.Message)
+	}
+
+	if len(msgs) > 0 {
+		panic(strings.Join(msgs, "; "))
+	}
+}
+
+// validateRules checks the raw value of a variable of the given type against the given rules.
+// Rules check each item of lists and each value of maps, and each broken rule is reported once.
+func validateRules(name, raw string, typ reflect.Type, rules []Rule) []errors.FieldViolation {
+	items, itemType, collection := ruleItems(raw, typ)
+	number := numberOf(itemType)
+
+	subject := name
+	if collection {
+		subject = fmt.Sprintf("%s items", name)
+	}
+
+	violations := []errors.FieldViolation{}
+	for _, rule := range rules {
+		for _, item := range items {
+			if msg, ok := rule.check(item, number); !ok {
+				violations = append(violations, errors.FieldViolation{
+					Field:   name,
+					Rule:    rule.name,
+					Message: fmt.Sprintf("%s %s", subject, msg),
+				})
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// ruleItems splits the raw value of a variable of the given type into the items checked by rules, along with their type.
+// Lists and maps are split as parseValue does, and their items are the list items and the map values.
+// Other values, including empty ones, are checked as a whole.
+func ruleItems(raw string, typ reflect.Type) ([]string, reflect.Type, bool) {
+	itemType, collection := ruleItemType(typ)
+	if !collection || raw == "" {
+		return []string{raw}, typ, false
+	}
+
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if typ.Kind() == reflect.Map {
+			_, item, _ = strings.Cut(item, "=")
+		}
+		items = append(items, strings.TrimSpace(item))
+	}
+
+	return items, itemType, true
+}
+
+// ruleItemType returns the type of the items checked by rules in values of the given type, and whether it is a list or a map.
+// Types that parse themselves (see encoding.TextUnmarshaler), like net.IP, are not split.
+func ruleItemType(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) || (typ.Kind() != reflect.Slice && typ.Kind() != reflect.Map) {
+		return typ, false
+	}
+	return typ.Elem(), true
+}
+
+// rulesFromTags builds the rules declared by the tags of a struct field: min, max, oneof (comma-separated),
+// pattern, notempty ("true") and scheme (comma-separated). Bounds are parsed like the field values (or their items, for lists and maps),
+// as in min:"1s" or max:"10MB".
+func rulesFromTags(field reflect.StructField) ([]Rule, error) {
+	rules := []Rule{}
+
+	for _, tag := range []string{"min", "max"} {
+		value, ok := field.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+
+		itemType, _ := ruleItemType(field.Type)
+		limit, err := numberOf(itemType).parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s tag '%s'", tag, value)
+		}
+
+		if tag == "min" {
+			rules = append(rules, Min(limit))
+		} else {
+			rules = append(rules, Max(limit))
+		}
+	}
+
+	if value, ok := field.Tag.Lookup("oneof"); ok {
+		rules = append(rules, OneOf(splitTag(value)...))
+	}
+
+	if value, ok := field.Tag.Lookup("pattern"); ok {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern tag '%s'", value)
+		}
+		rules = append(rules, patternRule(re))
+	}
+
+	if field.Tag.Get("notempty") == "true" {
+		rules = append(rules, NotEmpty())
+	}
+
+	if value, ok := field.Tag.Lookup("scheme"); ok {
+		rules = append(rules, Scheme(splitTag(value)...))
+	}
+
+	return rules, nil
+}
+
+// ruleTags describes the rules declared by the tags of a struct field, like "min=1", "oneof=DEBUG,INFO" or "notempty".
+// It returns nil when there are none.
+func ruleTags(field reflect.StructField) []string {
+	var rules []string
+	for _, tag := range []string{"min", "max", "oneof", "pattern", "notempty", "scheme"} {
+		value, ok := field.Tag.Lookup(tag)
+		switch {
+		case !ok:
+		case tag == "notempty":
+			if value == "true" {
+				rules = append(rules, tag)
+			}
+		case tag == "oneof" || tag == "scheme":
+			rules = append(rules, fmt.Sprintf("%s=%s", tag, strings.Join(splitTag(value), ",")))
+		default:
+			rules = append(rules, fmt.Sprintf("%s=%s", tag, value))
+		}
+	}
+	return rules
+}
+
+func splitTag(value string) []string {
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
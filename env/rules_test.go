@@ -0,0 +1,239 @@
+package env_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func TestRules(t *testing.T) {
+	loader := env.NewLoader(env.LoaderParams{
+		Source: env.MapSource{
+			"RULES_PORT":      "70000",
+			"RULES_LEVEL":     "verbose",
+			"RULES_NAME":      "Go Kit",
+			"RULES_BLANK":     "  ",
+			"RULES_URL":       "ftp://trivela.com.br",
+			"RULES_VALID_URL": "https://trivela.com.br",
+			"RULES_TIMEOUT":   "90s",
+			"RULES_BODY":      "2MB",
+			"RULES_PORTS":     "80,443",
+			"RULES_BAD_PORTS": "80, 0,70000",
+			"RULES_RETRIES":   "1s,5s",
+		},
+	})
+
+	tt := []struct {
+		name               string
+		rules              []env.Rule
+		expectedViolations []errors.FieldViolation
+	}{
+		{
+			name:  "RULES_PORT",
+			rules: []env.Rule{env.Min(1), env.Max(65535)},
+			expectedViolations: []errors.FieldViolation{
+				{Field: "RULES_PORT", Rule: "max", Message: "RULES_PORT must be less than or equal to 65535"},
+			},
+		},
+		{
+			name:  "RULES_LEVEL",
+			rules: []env.Rule{env.OneOf("DEBUG", "INFO")},
+			expectedViolations: []errors.FieldViolation{
+				{Field: "RULES_LEVEL", Rule: "oneof", Message: "RULES_LEVEL must be one of: DEBUG, INFO"},
+			},
+		},
+		{
+			name:  "RULES_NAME",
+			rules: []env.Rule{env.Pattern("^[a-z-]+$"), env.Min(1)},
+			expectedViolations: []errors.FieldViolation{
+				{Field: "RULES_NAME", Rule: "pattern", Message: "RULES_NAME must match the pattern ^[a-z-]+$"},
+				{Field: "RULES_NAME", Rule: "min", Message: "RULES_NAME must be greater than or equal to 1"},
+			},
+		},
+		{
+			name:  "RULES_BLANK",
+			rules: []env.Rule{env.NotEmpty()},
+			expectedViolations: []errors.FieldViolation{
+				{Field: "RULES_BLANK", Rule: "notempty", Message: "RULES_BLANK can't be blank"},
+			},
+		},
+		{
+			name:  "RULES_URL",
+			rules: []env.Rule{env.Scheme("http", "https")},
+			expectedViolations: []errors.FieldViolation{
+				{Field: "RULES_URL", Rule: "scheme", Message: "RULES_URL must be a URL with one of the schemes: http, https"},
+			},
+		},
+		{
+			name:  "RULES_VALID_URL",
+			rules: []env.Rule{env.Scheme("http", "https"), env.NotEmpty()},
+		},
+		{
+			name:  "RULES_MISSING",
+			rules: []env.Rule{env.Min(1), env.OneOf("a"), env.Pattern("^a$"), env.Scheme("https")},
+		},
+		{
+			name:  "RULES_UNSET",
+			rules: []env.Rule{env.NotEmpty()},
+			expectedViolations: []errors.FieldViolation{
+				{Field: "RULES_UNSET", Rule: "notempty", Message: "RULES_UNSET can't be blank"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := loader.Validate(tc.name, tc.rules...)
+			if len(tc.expectedViolations) == 0 {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			if violations := errors.Violations(err); !reflect.DeepEqual(tc.expectedViolations, violations) {
+				t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", tc.expectedViolations, violations)
+			}
+		})
+	}
+
+	t.Run("should panic when a MustGet rule is broken", func(t *testing.T) {
+		defer func() {
+			expectedPanicMsg := "RULES_PORT must be less than or equal to 65535"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+			}
+		}()
+
+		loader.MustGetInt("RULES_PORT", env.Min(1), env.Max(65535))
+	})
+
+	t.Run("should compare durations", func(t *testing.T) {
+		defer func() {
+			expectedPanicMsg := "RULES_TIMEOUT must be less than or equal to 1m0s"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+			}
+		}()
+
+		if res := loader.MustGetDuration("RULES_TIMEOUT", env.Min(float64(time.Second))); res != 90*time.Second {
+			t.Errorf("Mismatch duration response, got '%s'.", res)
+		}
+
+		loader.MustGetDuration("RULES_TIMEOUT", env.Max(float64(time.Minute)))
+	})
+
+	t.Run("should validate durations and byte sizes", func(t *testing.T) {
+		if err := loader.ValidateDuration("RULES_TIMEOUT", env.Min(float64(time.Second))); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		expected := []errors.FieldViolation{
+			{Field: "RULES_TIMEOUT", Rule: "max", Message: "RULES_TIMEOUT must be less than or equal to 1m0s"},
+		}
+		if violations := errors.Violations(loader.ValidateDuration("RULES_TIMEOUT", env.Max(float64(time.Minute)))); !reflect.DeepEqual(expected, violations) {
+			t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", expected, violations)
+		}
+
+		if err := loader.ValidateByteSize("RULES_BODY", env.Min(float64(env.Megabyte))); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		expected = []errors.FieldViolation{
+			{Field: "RULES_BODY", Rule: "max", Message: "RULES_BODY must be less than or equal to 1048576 bytes"},
+		}
+		if violations := errors.Violations(loader.ValidateByteSize("RULES_BODY", env.Max(float64(env.Mebibyte)))); !reflect.DeepEqual(expected, violations) {
+			t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", expected, violations)
+		}
+
+		expected = []errors.FieldViolation{
+			{Field: "RULES_NAME", Rule: "type", Message: "RULES_NAME must contain a valid time.Duration value"},
+		}
+		if violations := errors.Violations(loader.ValidateDuration("RULES_NAME", env.Min(0))); !reflect.DeepEqual(expected, violations) {
+			t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", expected, violations)
+		}
+	})
+
+	t.Run("should check each item of lists", func(t *testing.T) {
+		if res := loader.MustGetIntSlice("RULES_PORTS", env.Min(1), env.Max(65535)); !reflect.DeepEqual([]int{80, 443}, res) {
+			t.Errorf("Mismatch int slice response, got '%v'.", res)
+		}
+
+		if res := loader.MustGetDurationSlice("RULES_RETRIES", env.Min(float64(time.Second))); !reflect.DeepEqual([]time.Duration{time.Second, 5 * time.Second}, res) {
+			t.Errorf("Mismatch duration slice response, got '%v'.", res)
+		}
+
+		defer func() {
+			expectedPanicMsg := "RULES_BAD_PORTS items must be greater than or equal to 1; RULES_BAD_PORTS items must be less than or equal to 65535"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+			}
+		}()
+
+		loader.MustGetIntSlice("RULES_BAD_PORTS", env.Min(1), env.Max(65535))
+	})
+
+	t.Run("should compare byte sizes", func(t *testing.T) {
+		defer func() {
+			expectedPanicMsg := "RULES_BODY must be less than or equal to 1048576 bytes"
+			if e := recover(); e != expectedPanicMsg {
+				t.Errorf("Mismatch panic msg response. Expected '%s', got '%s'.", expectedPanicMsg, e)
+			}
+		}()
+
+		if res := loader.MustGetByteSize("RULES_BODY", env.Min(float64(env.Megabyte))); res != 2*env.Megabyte {
+			t.Errorf("Mismatch byte size response, got '%d'.", res)
+		}
+
+		loader.MustGetByteSize("RULES_BODY", env.Max(float64(env.Mebibyte)))
+	})
+}
+
+func TestLoadRules(t *testing.T) {
+	loader := env.NewLoader(env.LoaderParams{
+		Source: env.MapSource{
+			"RULES_PORT":    "0",
+			"RULES_LEVEL":   "verbose",
+			"RULES_TIMEOUT": "5s",
+			"RULES_BODY":    "2MB",
+			"RULES_PORTS":   "80,443",
+			"RULES_DELAYS":  "1s,2m",
+			"RULES_LIMITS":  "a=1,b=0",
+		},
+	})
+
+	var cfg struct {
+		Port     int             `env:"RULES_PORT" min:"1" max:"65535"`
+		Level    string          `env:"RULES_LEVEL" oneof:"DEBUG, INFO, WARNING"`
+		Endpoint string          `env:"RULES_ENDPOINT" default:"localhost:8080" scheme:"http,https"`
+		Name     string          `env:"RULES_NAME" default:"go-kit" pattern:"^[a-z-]+$" notempty:"true"`
+		Invalid  string          `env:"RULES_INVALID" default:"x" pattern:"("`
+		Token    string          `env:"RULES_TOKEN" notempty:"true"`
+		Timeout  time.Duration   `env:"RULES_TIMEOUT" min:"1s" max:"1m"`
+		Retry    *time.Duration  `env:"RULES_RETRY" default:"500ms" min:"1s"`
+		Body     env.ByteSize    `env:"RULES_BODY" min:"1KB" max:"1MiB"`
+		Upload   env.ByteSize    `env:"RULES_UPLOAD" default:"1GB" max:"ten"`
+		Ports    []int           `env:"RULES_PORTS" min:"1" max:"65535"`
+		Delays   []time.Duration `env:"RULES_DELAYS" max:"1m"`
+		Limits   map[string]int  `env:"RULES_LIMITS" min:"1"`
+	}
+
+	expected := []errors.FieldViolation{
+		{Field: "RULES_PORT", Rule: "min", Message: "RULES_PORT must be greater than or equal to 1"},
+		{Field: "RULES_LEVEL", Rule: "oneof", Message: "RULES_LEVEL must be one of: DEBUG, INFO, WARNING"},
+		{Field: "RULES_ENDPOINT", Rule: "scheme", Message: "RULES_ENDPOINT must be a URL with one of the schemes: http, https"},
+		{Field: "RULES_INVALID", Rule: "tag", Message: "RULES_INVALID has an invalid pattern tag '('"},
+		{Field: "RULES_TOKEN", Rule: "notempty", Message: "RULES_TOKEN can't be blank"},
+		{Field: "RULES_RETRY", Rule: "min", Message: "RULES_RETRY must be greater than or equal to 1s"},
+		{Field: "RULES_BODY", Rule: "max", Message: "RULES_BODY must be less than or equal to 1048576 bytes"},
+		{Field: "RULES_UPLOAD", Rule: "tag", Message: "RULES_UPLOAD has an invalid max tag 'ten'"},
+		{Field: "RULES_DELAYS", Rule: "max", Message: "RULES_DELAYS items must be less than or equal to 1m0s"},
+		{Field: "RULES_LIMITS", Rule: "min", Message: "RULES_LIMITS items must be greater than or equal to 1"},
+	}
+	if violations := errors.Violations(loader.Load(&cfg)); !reflect.DeepEqual(expected, violations) {
+		t.Errorf("Mismatch violations.\nExpected '%+v'\ngot      '%+v'.", expected, violations)
+	}
+}
@@ -0,0 +1,83 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// FileSuffix is the suffix of variables holding the path of a file with the value of another variable (like DB_PASSWORD_FILE).
+const FileSuffix = "_FILE"
+
+// SetSecretsDir sets the directory of secret files used by the package functions (see LoaderParams.SecretsDir).
+func SetSecretsDir(dir string) {
+	defaultLoader.mu.Lock()
+	defer defaultLoader.mu.Unlock()
+
+	defaultLoader.secretsDir = dir
+}
+
+// IsSensitive checks if the value of the given environment variable was read from a secret file by the package functions.
+// Sensitive values must be redacted anywhere configuration is printed.
+func IsSensitive(name string) bool {
+	return defaultLoader.IsSensitive(name)
+}
+
+// IsSensitive checks if the value of the given variable was read from a secret file.
+// Sensitive values must be redacted anywhere configuration is printed.
+func (l *Loader) IsSensitive(name string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.sensitive[name]
+}
+
+// resolve returns the value of the given variable. When it's empty, the value is read from:
+//   - the file named by the <NAME>_FILE variable;
+//   - the file named after the variable in the secrets directory, if it exists.
+//
+// Values read from files have their trailing newline trimmed, and are marked as sensitive.
+// Every resolved variable is tracked, along with its Origin (see Report).
+func (l *Loader) resolve(name string) (string, Origin, error) {
+	value, origin, err := l.resolveValue(name)
+	if err == nil {
+		l.track(name, value, origin)
+	}
+	return value, origin, err
+}
+
+func (l *Loader) resolveValue(name string) (string, Origin, error) {
+	if value, _ := l.source.Lookup(name); value != "" {
+		return value, OriginEnv, nil
+	}
+
+	path, _ := l.source.Lookup(name + FileSuffix)
+	if path == "" {
+		l.mu.RLock()
+		dir := l.secretsDir
+		l.mu.RUnlock()
+
+		if dir == "" {
+			return "", OriginUnset, nil
+		}
+
+		path = filepath.Join(dir, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return "", OriginUnset, nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", OriginUnset, errors.New("could not read the value of %s from %s", name, path).WithRootError(err)
+	}
+
+	l.mu.Lock()
+	l.sensitive[name] = true
+	l.mu.Unlock()
+
+	value := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(value, "\r"), OriginFile, nil
+}
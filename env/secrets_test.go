@@ -0,0 +1,103 @@
+package env_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func TestSecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "password"), "file-password\n")
+	writeFile(t, filepath.Join(dir, "SECRET_TOKEN"), "dir-token\r\n")
+	writeFile(t, filepath.Join(dir, "SECRET_USER"), "dir-user")
+
+	loader := env.NewLoader(env.LoaderParams{
+		Source: env.MapSource{
+			"SECRET_PASSWORD_FILE": filepath.Join(dir, "password"),
+			"SECRET_USER":          "env-user",
+			"SECRET_MISSING_FILE":  filepath.Join(dir, "missing"),
+		},
+		SecretsDir: dir,
+	})
+
+	tt := []struct {
+		name              string
+		expectedValue     string
+		expectedSensitive bool
+	}{
+		{name: "SECRET_PASSWORD", expectedValue: "file-password", expectedSensitive: true},
+		{name: "SECRET_TOKEN", expectedValue: "dir-token", expectedSensitive: true},
+		{name: "SECRET_USER", expectedValue: "env-user", expectedSensitive: false},
+		{name: "SECRET_MISSING", expectedValue: "", expectedSensitive: false},
+		{name: "SECRET_UNKNOWN", expectedValue: "", expectedSensitive: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if res := loader.GetString(tc.name); res != tc.expectedValue {
+				t.Errorf("Mismatch response. Expected '%s', got '%s'.", tc.expectedValue, res)
+			}
+
+			if res := loader.IsSensitive(tc.name); res != tc.expectedSensitive {
+				t.Errorf("Mismatch sensitive response. Expected '%t', got '%t'.", tc.expectedSensitive, res)
+			}
+		})
+	}
+
+	t.Run("should report unreadable secret files when loading structs", func(t *testing.T) {
+		var cfg struct {
+			Missing string `env:"SECRET_MISSING" default:"ignored"`
+		}
+
+		expected := []errors.FieldViolation{
+			{
+				Field:   "SECRET_MISSING",
+				Rule:    "file",
+				Message: "could not read the value of SECRET_MISSING from " + filepath.Join(dir, "missing"),
+			},
+		}
+		if violations := errors.Violations(loader.Load(&cfg)); !reflect.DeepEqual(expected, violations) {
+			t.Errorf("Mismatch violations. Expected '%+v', got '%+v'.", expected, violations)
+		}
+	})
+
+	t.Run("should panic with the secret file error when requiring values", func(t *testing.T) {
+		path := filepath.Join(dir, "missing")
+		expectedPanicMsg := "could not read the value of SECRET_MISSING from " + path + ": open " + path + ": no such file or directory"
+
+		for name, mustGet := range map[string]func(){
+			"MustGetString": func() { loader.MustGetString("SECRET_MISSING") },
+			"MustGetInt":    func() { loader.MustGetInt("SECRET_MISSING") },
+		} {
+			func() {
+				defer func() {
+					if e := recover(); e != expectedPanicMsg {
+						t.Errorf("Mismatch %s panic msg response. Expected '%s', got '%v'.", name, expectedPanicMsg, e)
+					}
+				}()
+
+				mustGet()
+			}()
+		}
+	})
+}
+
+func TestSetSecretsDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "SECRET_DEFAULT_LOADER"), "secret\n")
+
+	env.SetSecretsDir(dir)
+	defer env.SetSecretsDir("")
+
+	if res := env.MustGetString("SECRET_DEFAULT_LOADER"); res != "secret" {
+		t.Errorf("Mismatch response, got '%s'.", res)
+	}
+
+	if !env.IsSensitive("SECRET_DEFAULT_LOADER") {
+		t.Error("expected value read from secrets directory to be sensitive")
+	}
+}
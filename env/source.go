@@ -0,0 +1,57 @@
+package env
+
+import "os"
+
+// Source provides the values of configuration variables.
+type Source interface {
+	// Lookup returns the value of the given variable, and whether it is set.
+	Lookup(name string) (string, bool)
+}
+
+type osSource struct{}
+
+// NewOSSource creates a Source that reads the process environment.
+func NewOSSource() Source {
+	return osSource{}
+}
+
+func (osSource) Lookup(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+// MapSource is an in-memory Source, useful for tests since it doesn't touch the process environment.
+type MapSource map[string]string
+
+// Lookup returns the value of the given variable, and whether it is set.
+func (m MapSource) Lookup(name string) (string, bool) {
+	value, ok := m[name]
+	return value, ok
+}
+
+// NewFileSource creates a Source with the variables declared in a .env file (see ReadDotEnv).
+// The file is read once, when the Source is created.
+func NewFileSource(path string) (Source, error) {
+	values, err := ReadDotEnv(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return MapSource(values), nil
+}
+
+type chainSource []Source
+
+// NewChainSource creates a Source that looks up variables in the given sources, in order.
+// The first Source where a variable is set takes precedence.
+func NewChainSource(sources ...Source) Source {
+	return chainSource(sources)
+}
+
+func (c chainSource) Lookup(name string) (string, bool) {
+	for _, source := range c {
+		if value, ok := source.Lookup(name); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,49 @@
+package env_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/trivelaapp/go-kit/env"
+)
+
+func TestSources(t *testing.T) {
+	t.Setenv("SOURCE_OS", "os")
+
+	path := filepath.Join(t.TempDir(), ".env")
+	writeFile(t, path, "SOURCE_FILE=file\nSOURCE_MAP=file")
+
+	file, err := env.NewFileSource(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	source := env.NewChainSource(env.MapSource{"SOURCE_MAP": "map", "SOURCE_EMPTY": ""}, file, env.NewOSSource())
+
+	tt := []struct {
+		name          string
+		expectedValue string
+		expectedOk    bool
+	}{
+		{name: "SOURCE_MAP", expectedValue: "map", expectedOk: true},
+		{name: "SOURCE_FILE", expectedValue: "file", expectedOk: true},
+		{name: "SOURCE_OS", expectedValue: "os", expectedOk: true},
+		{name: "SOURCE_EMPTY", expectedValue: "", expectedOk: true},
+		{name: "SOURCE_MISSING", expectedValue: "", expectedOk: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := source.Lookup(tc.name)
+			if value != tc.expectedValue || ok != tc.expectedOk {
+				t.Errorf("Mismatch response. Expected '%s' (%t), got '%s' (%t).", tc.expectedValue, tc.expectedOk, value, ok)
+			}
+		})
+	}
+}
+
+func TestNewFileSourceMissingFile(t *testing.T) {
+	if _, err := env.NewFileSource(filepath.Join(t.TempDir(), ".env")); err == nil {
+		t.Error("expected error when the file doesn't exist")
+	}
+}
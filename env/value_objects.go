@@ -0,0 +1,21 @@
+package env
+
+import "github.com/trivelaapp/go-kit/errors"
+
+var (
+	// ErrInvalidConfiguration indicates that environment variables are missing or invalid.
+	ErrInvalidConfiguration errors.CustomError = errors.MustRegister(errors.CodeDefinition{
+		Code:        "INVALID_CONFIGURATION",
+		Kind:        errors.KindInvalidInput,
+		Message:     "invalid configuration",
+		Description: "Environment variables are missing or invalid. The invalid variables are detailed by its violations.",
+	})
+
+	// ErrUnknownEnvironment indicates that an environment label is not registered.
+	ErrUnknownEnvironment errors.CustomError = errors.MustRegister(errors.CodeDefinition{
+		Code:        "UNKNOWN_ENVIRONMENT",
+		Kind:        errors.KindInvalidInput,
+		Message:     "unknown environment",
+		Description: "The environment label is not registered. Custom labels can be registered with env.RegisterEnvironmentLabel.",
+	})
+)
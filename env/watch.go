@@ -0,0 +1,293 @@
+package env
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// WatcherParams encapsulates the parameters of a Watcher.
+type WatcherParams struct {
+	// Paths are the configuration files, in precedence order. Each one can be either a .env file (see ReadDotEnv),
+	// or a directory with one file per variable, named after it (like a mounted Kubernetes ConfigMap).
+	Paths []string
+	// Interval is how often Paths are polled for changes. Zero disables polling.
+	Interval time.Duration
+	// Signals trigger a reload when received. Defaults to SIGHUP.
+	Signals []os.Signal
+	// OnError is called when a reload triggered by Start fails. The running values are kept.
+	OnError func(err error)
+}
+
+type subscription struct {
+	validate func(raw string) error
+	notify   func(raw string)
+}
+
+// Watcher is a Source backed by configuration files, that can be reloaded while the application is running.
+// Components can subscribe to changes of specific variables (see Subscribe), and reloads are validated before being applied.
+//
+// It's usually combined with other sources by a Loader:
+//
+//	watcher, err := env.NewWatcher(env.WatcherParams{Paths: []string{"/etc/config"}, Interval: time.Minute})
+//	loader := env.NewLoader(env.LoaderParams{Source: env.NewChainSource(watcher, env.NewOSSource())})
+type Watcher struct {
+	params WatcherParams
+
+	reloadMu      sync.Mutex
+	mu            sync.RWMutex
+	values        map[string]string
+	validators    []func(loader *Loader) error
+	subscriptions map[string][]subscription
+}
+
+// NewWatcher creates a new Watcher with the given parameters, reading its files for the first time.
+func NewWatcher(params WatcherParams) (*Watcher, error) {
+	if len(params.Signals) == 0 {
+		params.Signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	values, err := readSnapshot(params.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		params:        params,
+		values:        values,
+		subscriptions: map[string][]subscription{},
+	}, nil
+}
+
+// Lookup returns the current value of the given variable, and whether it is set.
+func (w *Watcher) Lookup(name string) (string, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	value, ok := w.values[name]
+	return value, ok
+}
+
+// AddValidator registers a validation of reloaded values. The validator receives a Loader of the new values,
+// so it can, for example, check that a configuration struct still loads. Reloads that fail any validation are rejected.
+func (w *Watcher) AddValidator(validator func(loader *Loader) error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.validators = append(w.validators, validator)
+}
+
+// Subscribe registers a callback for changes of the given variable of the Watcher, called with its new value parsed into T.
+// The callback receives the zero value of T when the variable is removed.
+// Reloads where the variable can't be parsed into T are rejected. It fails if the current value can't be parsed into T.
+// It waits for running reloads, so it must not be called by callbacks or validators.
+func Subscribe[T any](w *Watcher, name string, callback func(value T)) error {
+	validate := func(raw string) error {
+		if raw == "" {
+			return nil
+		}
+
+		var value T
+		if err := parse(raw, &value); err != nil {
+			return errors.New("%s must contain a valid %s value", name, reflect.TypeOf(value)).WithKind(errors.KindInvalidInput).WithRootError(err)
+		}
+		return nil
+	}
+
+	notify := func(raw string) {
+		var value T
+		if raw != "" {
+			_ = parse(raw, &value)
+		}
+		callback(value)
+	}
+
+	// Subscriptions wait for running reloads, so they're never left out of the validation of values they're notified about.
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := validate(w.values[name]); err != nil {
+		return err
+	}
+
+	w.subscriptions[name] = append(w.subscriptions[name], subscription{validate: validate, notify: notify})
+	return nil
+}
+
+// Reload reads the Watcher files again. If the new values pass all validations, they replace the running ones,
+// and the subscribers of changed variables are notified. Otherwise, the running values are kept.
+func (w *Watcher) Reload() error {
+	// Reloads are serialized, so values validated by one reload can't be replaced by a concurrent one before being applied.
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	values, err := readSnapshot(w.params.Paths)
+	if err != nil {
+		return err
+	}
+
+	w.mu.RLock()
+	unchanged := reflect.DeepEqual(w.values, values)
+	validators := append([]func(loader *Loader) error{}, w.validators...)
+	subscriptions := map[string][]subscription{}
+	for name, subs := range w.subscriptions {
+		subscriptions[name] = append([]subscription{}, subs...)
+	}
+	w.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	// Validations run without holding the lock, so validators can read the Watcher.
+	if err := validate(values, subscriptions, validators); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+
+	// The validated subscriptions are the notified ones, so no subscriber receives a value it couldn't parse.
+	notifications := []func(){}
+	for name, subs := range subscriptions {
+		if w.values[name] == values[name] {
+			continue
+		}
+
+		for _, sub := range subs {
+			notify, raw := sub.notify, values[name]
+			notifications = append(notifications, func() { notify(raw) })
+		}
+	}
+
+	w.values = values
+	w.mu.Unlock()
+
+	// Subscribers are notified without holding the lock, so they can read the Watcher.
+	for _, notify := range notifications {
+		notify()
+	}
+
+	return nil
+}
+
+// validate checks if the candidate values can be parsed by all subscribers and pass all validators.
+func validate(values map[string]string, subscriptions map[string][]subscription, validators []func(loader *Loader) error) error {
+	violations := []errors.FieldViolation{}
+	for name, subs := range subscriptions {
+		for _, sub := range subs {
+			if err := sub.validate(values[name]); err != nil {
+				violations = append(violations, errors.FieldViolation{Field: name, Rule: "type", Message: err.Error()})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return ErrInvalidConfiguration.WithViolations(violations...)
+	}
+
+	loader := NewLoader(LoaderParams{Source: MapSource(values)})
+	for _, validator := range validators {
+		if err := validator(loader); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start reloads the Watcher when its files change (if polling is enabled), or when one of its signals is received,
+// until the given context is done. Failed reloads are reported to OnError.
+func (w *Watcher) Start(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, w.params.Signals...)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		var ticks <-chan time.Time
+		if w.params.Interval > 0 {
+			ticker := time.NewTicker(w.params.Interval)
+			defer ticker.Stop()
+			ticks = ticker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+			case <-ticks:
+			}
+
+			if err := w.Reload(); err != nil && w.params.OnError != nil {
+				w.params.OnError(err)
+			}
+		}
+	}()
+}
+
+// readSnapshot reads the values of all given paths. The first path where a variable is declared takes precedence.
+func readSnapshot(paths []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.New("could not read %s", path).WithRootError(err)
+		}
+
+		var pathValues map[string]string
+		if info.IsDir() {
+			pathValues, err = readConfigDir(path)
+		} else {
+			pathValues, err = ReadDotEnv(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range pathValues {
+			if _, ok := values[name]; !ok {
+				values[name] = value
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// readConfigDir reads a directory with one file per variable, named after it.
+// Hidden files are skipped, since Kubernetes uses them to swap mounted ConfigMaps atomically.
+func readConfigDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.New("could not read %s", dir).WithRootError(err)
+	}
+
+	values := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("could not read %s", path).WithRootError(err)
+		}
+
+		values[entry.Name()] = strings.TrimSuffix(string(content), "\n")
+	}
+
+	return values, nil
+}
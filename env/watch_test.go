@@ -0,0 +1,257 @@
+package env_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/trivelaapp/go-kit/env"
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	writeFile(t, path, "WATCH_LEVEL=INFO\nWATCH_LIMIT=10")
+
+	configMap := filepath.Join(dir, "config")
+	if err := os.Mkdir(configMap, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(configMap, "WATCH_LEVEL"), "DEBUG\n")
+	writeFile(t, filepath.Join(configMap, "..data"), "ignored")
+
+	watcher, err := env.NewWatcher(env.WatcherParams{Paths: []string{configMap, path}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loader := env.NewLoader(env.LoaderParams{Source: watcher})
+	if res := loader.MustGetString("WATCH_LEVEL"); res != "DEBUG" {
+		t.Errorf("Expected the first path to take precedence, got '%s'.", res)
+	}
+
+	limits := []int{}
+	if err := env.Subscribe(watcher, "WATCH_LIMIT", func(limit int) { limits = append(limits, limit) }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	levels := []string{}
+	if err := env.Subscribe(watcher, "WATCH_LEVEL", func(level string) { levels = append(levels, level) }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	watcher.AddValidator(func(l *env.Loader) error {
+		if l.GetInt("WATCH_LIMIT") > 100 {
+			return errors.New("limit too high").WithKind(errors.KindInvalidInput)
+		}
+		return nil
+	})
+
+	t.Run("should notify subscribers of changed variables", func(t *testing.T) {
+		writeFile(t, path, "WATCH_LEVEL=INFO\nWATCH_LIMIT=20")
+
+		if err := watcher.Reload(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(limits) != 1 || limits[0] != 20 || len(levels) != 0 {
+			t.Errorf("Mismatch notifications, got limits '%v' and levels '%v'.", limits, levels)
+		}
+
+		if res := loader.MustGetInt("WATCH_LIMIT"); res != 20 {
+			t.Errorf("Expected reloaded value, got '%d'.", res)
+		}
+	})
+
+	t.Run("should reject updates that can't be parsed by subscribers", func(t *testing.T) {
+		writeFile(t, path, "WATCH_LEVEL=INFO\nWATCH_LIMIT=unlimited")
+
+		err := watcher.Reload()
+		if errors.Code(err) != errors.Code(env.ErrInvalidConfiguration) {
+			t.Errorf("Wrong error code, got: %s", errors.Code(err))
+		}
+
+		if res := loader.MustGetInt("WATCH_LIMIT"); res != 20 || len(limits) != 1 {
+			t.Errorf("Expected running value to be kept, got '%d'.", res)
+		}
+	})
+
+	t.Run("should reject updates that fail validation", func(t *testing.T) {
+		writeFile(t, path, "WATCH_LEVEL=INFO\nWATCH_LIMIT=1000")
+
+		if err := watcher.Reload(); err == nil || err.Error() != "limit too high" {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+
+		if res := loader.MustGetInt("WATCH_LIMIT"); res != 20 || len(limits) != 1 {
+			t.Errorf("Expected running value to be kept, got '%d'.", res)
+		}
+	})
+
+	t.Run("should let validators read the running values", func(t *testing.T) {
+		writeFile(t, path, "WATCH_LEVEL=INFO\nWATCH_LIMIT=20\nWATCH_UNSUBSCRIBED=1")
+
+		running := ""
+		watcher.AddValidator(func(l *env.Loader) error {
+			running, _ = watcher.Lookup("WATCH_LIMIT")
+			return nil
+		})
+
+		done := make(chan error, 1)
+		go func() { done <- watcher.Reload() }()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Reload didn't finish, validators can't read the Watcher")
+		}
+
+		if running != "20" {
+			t.Errorf("Expected validator to read the running value, got '%s'.", running)
+		}
+	})
+
+	t.Run("should notify subscribers of removed variables with zero values", func(t *testing.T) {
+		writeFile(t, path, "WATCH_LEVEL=INFO")
+
+		if err := watcher.Reload(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(limits) != 2 || limits[1] != 0 {
+			t.Errorf("Mismatch notifications, got limits '%v'.", limits)
+		}
+	})
+}
+
+func TestWatcherStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeFile(t, path, "WATCH_TIMEOUT=1s")
+
+	watcher, err := env.NewWatcher(env.WatcherParams{Paths: []string{path}, Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	timeouts := make(chan time.Duration, 2)
+	if err := env.Subscribe(watcher, "WATCH_TIMEOUT", func(timeout time.Duration) { timeouts <- timeout }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.Start(ctx)
+
+	t.Run("should reload when files change", func(t *testing.T) {
+		writeFile(t, path, "WATCH_TIMEOUT=2s")
+
+		select {
+		case timeout := <-timeouts:
+			if timeout != 2*time.Second {
+				t.Errorf("Mismatch notification, got '%s'.", timeout)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected a notification after the file changed")
+		}
+	})
+
+	t.Run("should reload when SIGHUP is received", func(t *testing.T) {
+		watcher, err := env.NewWatcher(env.WatcherParams{Paths: []string{path}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		reloaded := make(chan time.Duration, 1)
+		if err := env.Subscribe(watcher, "WATCH_TIMEOUT", func(timeout time.Duration) { reloaded <- timeout }); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		watcher.Start(ctx)
+		time.Sleep(10 * time.Millisecond)
+
+		writeFile(t, path, "WATCH_TIMEOUT=3s")
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := process.Signal(syscall.SIGHUP); err != nil {
+			t.Skipf("could not send SIGHUP: %v", err)
+		}
+
+		select {
+		case timeout := <-reloaded:
+			if timeout != 3*time.Second {
+				t.Errorf("Mismatch notification, got '%s'.", timeout)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected a notification after SIGHUP")
+		}
+	})
+}
+
+func TestSubscribeInvalidCurrentValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeFile(t, path, "WATCH_PORT=http")
+
+	watcher, err := env.NewWatcher(env.WatcherParams{Paths: []string{path}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := env.Subscribe(watcher, "WATCH_PORT", func(int) {}); errors.Kind(err) != errors.KindInvalidInput {
+		t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+	}
+}
+
+func TestSubscribeDuringReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeFile(t, path, "WATCH_PORT=8080")
+
+	watcher, err := env.NewWatcher(env.WatcherParams{Paths: []string{path}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	validating, release := make(chan struct{}), make(chan struct{})
+	watcher.AddValidator(func(l *env.Loader) error {
+		close(validating)
+		<-release
+		return nil
+	})
+
+	writeFile(t, path, "WATCH_PORT=http")
+
+	done := make(chan error, 1)
+	go func() { done <- watcher.Reload() }()
+	<-validating
+
+	subscribed := make(chan error, 1)
+	ports := []int{}
+	go func() {
+		subscribed <- env.Subscribe(watcher, "WATCH_PORT", func(port int) { ports = append(ports, port) })
+	}()
+
+	// Gives the subscription the chance to be registered while the reload is validating.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := <-subscribed; errors.Kind(err) != errors.KindInvalidInput {
+		t.Errorf("Expected subscription to be validated against the reloaded value, got: %v", err)
+	}
+
+	if len(ports) != 0 {
+		t.Errorf("Expected subscriber not to be notified with values it wasn't validated against, got '%v'.", ports)
+	}
+}
@@ -1,8 +1,11 @@
 package errors
 
 import (
+	"context"
 	e "errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // CodeType is a string that contains error's code description.
@@ -16,11 +19,18 @@ type KindType string
 // since its Kind and Code attributes are the keys to express its semantic and uniqueness, respectively.
 // It should be generated once by the peace of code that found the error (because it's where we have more context about the error),
 // and be by passed to the upper layers of the application.
+// Non comparable attributes are kept behind pointers, so CustomErrors can still be compared with ==.
 type CustomError struct {
-	kind    KindType
-	code    CodeType
-	rootErr error
-	message string
+	kind          KindType
+	code          CodeType
+	rootErr       error
+	message       string
+	publicMessage string
+	stack         *stack
+	fields        *fieldSet
+	violations    *violationList
+	retry         retryPolicy
+	retryAfter    time.Duration
 }
 
 const (
@@ -42,15 +52,32 @@ const (
 	KindUnauthorized KindType = "UNAUTHORIZED"
 	// KindResourceExhausted indicates some resource has been exhausted, perhaps a per-user quota, or perhaps the entire file system is out of space.
 	KindResourceExhausted KindType = "RESOURCE_EXHAUSTED"
+	// KindTimeout are errors caused by operations that didn't complete before their deadlines.
+	KindTimeout KindType = "TIMEOUT"
+	// KindUnavailable are errors caused by dependencies (or the application itself) temporarily unavailable.
+	KindUnavailable KindType = "UNAVAILABLE"
+	// KindCanceled are errors caused by operations canceled by the caller.
+	KindCanceled KindType = "CANCELED"
+	// KindFailedPrecondition are errors caused by operations rejected because the system is not in the state required for them.
+	KindFailedPrecondition KindType = "FAILED_PRECONDITION"
+	// KindNotImplemented are errors caused by operations not implemented or not supported.
+	KindNotImplemented KindType = "NOT_IMPLEMENTED"
 )
 
 // New returns a new instance of CustomError with the given message.
+// The current call stack is attached when stack trace capture is enabled (see SetStackTraceEnabled).
 func New(message string, args ...interface{}) CustomError {
-	return CustomError{
+	ce := CustomError{
 		kind:    KindUnexpected,
 		code:    CodeUnknown,
 		message: fmt.Sprintf(message, args...),
 	}
+
+	if isStackTraceEnabled() {
+		ce.stack = callers(2)
+	}
+
+	return ce
 }
 
 // NewMissingRequiredDependency creates a new error that indicates a missing required dependency.
@@ -60,8 +87,9 @@ func NewMissingRequiredDependency(name string) error {
 }
 
 // NewValidationError creates a Validation error.
-func NewValidationError(desc string) error {
-	return New(desc).WithKind(KindInvalidInput).WithCode("VALIDATION_ERROR")
+// The FieldViolations that caused the error can be optionally informed.
+func NewValidationError(desc string, violations ...FieldViolation) error {
+	return New(desc).WithKind(KindInvalidInput).WithCode("VALIDATION_ERROR").WithViolations(violations...)
 }
 
 // WithKind return a copy of the CustomError with the given KindType filled.
@@ -77,8 +105,27 @@ func (ce CustomError) WithCode(code CodeType) CustomError {
 }
 
 // WithRootError returns a copy of the CustomError with the RootError filled.
+// When the given error is not a CustomError, the current call stack is attached if stack trace capture is enabled
+// and the CustomError doesn't have one yet.
+// CustomErrors with the default kind wrapping context.DeadlineExceeded or context.Canceled are classified as
+// KindTimeout and KindCanceled, respectively.
 func (ce CustomError) WithRootError(err error) CustomError {
 	ce.rootErr = err
+
+	if ce.kind == KindUnexpected {
+		switch {
+		case e.Is(err, context.DeadlineExceeded):
+			ce.kind = KindTimeout
+		case e.Is(err, context.Canceled):
+			ce.kind = KindCanceled
+		}
+	}
+
+	var customError CustomError
+	if ce.stack == nil && err != nil && !e.As(err, &customError) && isStackTraceEnabled() {
+		ce.stack = callers(2)
+	}
+
 	return ce
 }
 
@@ -91,13 +138,43 @@ func (ce CustomError) Error() string {
 	return msg
 }
 
+// Unwrap returns the RootError attached into the CustomError.
+// It allows the standard library errors.Is and errors.As to inspect the RootError chain.
+func (ce CustomError) Unwrap() error {
+	return ce.rootErr
+}
+
+// Is reports whether the CustomError matches the target error. Two CustomErrors match when they have the same Kind and Code.
+// Since CodeUnknown doesn't identify any error, CustomErrors without Code only match when their messages are equal too.
+func (ce CustomError) Is(target error) bool {
+	t, ok := target.(CustomError)
+	if !ok {
+		return false
+	}
+
+	if ce.kind != t.kind || ce.code != t.code {
+		return false
+	}
+
+	return ce.code != CodeUnknown || ce.message == t.message
+}
+
 // RootError tries to convert the given error into a CustomError.
 // If so, it recursively tries to find the root error (non CustomError) in a CustomError RootError chain and returns its message.
+// For MultiErrors, the messages of the root errors of all aggregated errors are returned.
 func RootError(err error) string {
 	if err == nil {
 		return ""
 	}
 
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		roots := make([]string, 0, len(multiErr.errs))
+		for _, err := range multiErr.errs {
+			roots = append(roots, RootError(err))
+		}
+		return strings.Join(roots, "; ")
+	}
+
 	var customError CustomError
 	if e.As(err, &customError) {
 		if customError.rootErr == nil {
@@ -112,7 +189,12 @@ func RootError(err error) string {
 
 // Kind this method receives an error, then compares its interface type with the CustomError interface.
 // If the interfaces types matches, returns its kind.
+// For MultiErrors, it returns the kind of the aggregated error with the highest precedence.
 func Kind(err error) KindType {
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		return Kind(multiErr.prevailing())
+	}
+
 	var customError CustomError
 	if e.As(err, &customError) {
 		return customError.kind
@@ -122,7 +204,12 @@ func Kind(err error) KindType {
 
 // Kind this method receives an error, then compares its interface type with the CustomError interface.
 // If the interfaces types matches, returns its Code.
+// For MultiErrors, it returns the code of the aggregated error with the highest Kind precedence.
 func Code(err error) CodeType {
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		return Code(multiErr.prevailing())
+	}
+
 	var customError CustomError
 	if e.As(err, &customError) {
 		return customError.code
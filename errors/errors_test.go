@@ -1,8 +1,14 @@
 package errors_test
 
 import (
+	"context"
+	"encoding/json"
 	e "errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/trivelaapp/go-kit/errors"
 )
@@ -105,9 +111,63 @@ func TestNewValidationError(t *testing.T) {
 		if errors.Code(err) != errors.CodeType("VALIDATION_ERROR") {
 			t.Errorf("Wrong error code, got: %s", errors.Code(err))
 		}
+
+		if violations := errors.Violations(err); len(violations) != 0 {
+			t.Errorf("Expected no violations, got: %v", violations)
+		}
+	})
+
+	t.Run("should produce a validation error with field violations", func(t *testing.T) {
+		violation := errors.FieldViolation{Field: "items[0].name", Rule: "required", Message: "name is required"}
+		err := errors.NewValidationError("invalid order", violation)
+
+		if violations := errors.Violations(err); !reflect.DeepEqual(violations, []errors.FieldViolation{violation}) {
+			t.Errorf("Wrong violations, got: %v", violations)
+		}
 	})
 }
 
+func TestViolations(t *testing.T) {
+	first := errors.FieldViolation{Field: "name", Rule: "required", Message: "name is required"}
+	second := errors.FieldViolation{Field: "age", Rule: "min", Message: "age must be at least 18"}
+	third := errors.FieldViolation{Field: "email", Rule: "email", Message: "email is invalid"}
+
+	tt := []struct {
+		name               string
+		err                error
+		expectedViolations []errors.FieldViolation
+	}{
+		{
+			name:               "go native error",
+			err:                e.New("new error"),
+			expectedViolations: []errors.FieldViolation{},
+		},
+		{
+			name:               "custom error with appended violations",
+			err:                errors.New("some message").WithViolations(first).WithViolations(second),
+			expectedViolations: []errors.FieldViolation{first, second},
+		},
+		{
+			name:               "chain of custom errors with violations",
+			err:                errors.New("head error").WithViolations(first).WithRootError(errors.New("tail error").WithViolations(second)),
+			expectedViolations: []errors.FieldViolation{first, second},
+		},
+		{
+			name:               "multi error with violations",
+			err:                errors.Join(errors.NewValidationError("first", first, second), errors.NewValidationError("second", third)),
+			expectedViolations: []errors.FieldViolation{first, second, third},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if violations := errors.Violations(tc.err); !reflect.DeepEqual(violations, tc.expectedViolations) {
+				t.Errorf("Expected violations to be '%v': received '%v'", tc.expectedViolations, violations)
+			}
+		})
+	}
+}
+
 func TestKind(t *testing.T) {
 	tt := []struct {
 		name         string
@@ -129,6 +189,26 @@ func TestKind(t *testing.T) {
 			err:          errors.New("some message").WithKind("some kind"),
 			expectedKind: "some kind",
 		},
+		{
+			name:         "custom error wrapping context.DeadlineExceeded",
+			err:          errors.New("some message").WithRootError(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)),
+			expectedKind: errors.KindTimeout,
+		},
+		{
+			name:         "custom error wrapping context.Canceled",
+			err:          errors.New("some message").WithRootError(context.Canceled),
+			expectedKind: errors.KindCanceled,
+		},
+		{
+			name:         "custom error with non-default kind wrapping context.DeadlineExceeded",
+			err:          errors.New("some message").WithKind(errors.KindUnavailable).WithRootError(context.DeadlineExceeded),
+			expectedKind: errors.KindUnavailable,
+		},
+		{
+			name:         "not implemented error",
+			err:          errors.ErrNotImplemented,
+			expectedKind: errors.KindNotImplemented,
+		},
 	}
 
 	for _, tc := range tt {
@@ -241,3 +321,786 @@ func TestRootError(t *testing.T) {
 		})
 	}
 }
+
+func TestStack(t *testing.T) {
+	t.Run("should not capture the call stack by default", func(t *testing.T) {
+		if stack := errors.Stack(errors.New("mocked message")); stack != nil {
+			t.Errorf("expected no stack trace, got %v", stack)
+		}
+	})
+
+	t.Run("should capture the call stack when WithStackTrace is called", func(t *testing.T) {
+		stack := errors.Stack(errors.New("mocked message").WithStackTrace())
+		if len(stack) == 0 {
+			t.Fatal("expected stack trace to be captured")
+		}
+
+		if !strings.HasSuffix(stack[0].Function, "TestStack.func2") {
+			t.Errorf("expected first frame to be the caller, got '%s'", stack[0].Function)
+		}
+	})
+
+	t.Run("should capture the call stack when enabled", func(t *testing.T) {
+		errors.SetStackTraceEnabled(true)
+		defer errors.SetStackTraceEnabled(false)
+
+		if stack := errors.Stack(errors.New("mocked message")); len(stack) == 0 {
+			t.Error("expected stack trace to be captured by New")
+		}
+
+		if stack := errors.Stack(errors.ErrMock); stack != nil {
+			t.Errorf("expected errors built before enabling to have no stack trace, got %v", stack)
+		}
+	})
+
+	t.Run("should capture the call stack when wrapping a non CustomError", func(t *testing.T) {
+		errors.SetStackTraceEnabled(true)
+		defer errors.SetStackTraceEnabled(false)
+
+		if stack := errors.Stack(errors.ErrMock.(errors.CustomError).WithRootError(e.New("root error"))); len(stack) == 0 {
+			t.Error("expected stack trace to be captured by WithRootError")
+		}
+	})
+
+	t.Run("should return the deepest call stack of the chain", func(t *testing.T) {
+		inner := errors.New("inner error").WithStackTrace()
+		outer := errors.New("outer error").WithRootError(inner).WithStackTrace()
+
+		if stack := errors.Stack(outer); stack[0].Line != errors.Stack(inner)[0].Line {
+			t.Errorf("expected the inner stack trace, got %v", stack)
+		}
+	})
+}
+
+func TestFields(t *testing.T) {
+	tt := []struct {
+		name           string
+		err            error
+		expectedFields map[string]any
+	}{
+		{
+			name:           "go native error",
+			err:            e.New("new error"),
+			expectedFields: map[string]any{},
+		},
+		{
+			name:           "custom error without fields",
+			err:            errors.New("some message"),
+			expectedFields: map[string]any{},
+		},
+		{
+			name:           "custom error with fields",
+			err:            errors.New("some message").WithField("order_id", "123").WithFields(map[string]any{"user_id": 1}),
+			expectedFields: map[string]any{"order_id": "123", "user_id": 1},
+		},
+		{
+			name:           "custom error with overwritten field",
+			err:            errors.New("some message").WithField("order_id", "123").WithField("order_id", "456"),
+			expectedFields: map[string]any{"order_id": "456"},
+		},
+		{
+			name: "chain of custom errors with fields",
+			err: errors.New("head error").WithField("order_id", "456").WithRootError(
+				errors.New("tail error").WithFields(map[string]any{"order_id": "123", "user_id": 1}),
+			),
+			expectedFields: map[string]any{"order_id": "456", "user_id": 1},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if fields := errors.Fields(tc.err); !reflect.DeepEqual(fields, tc.expectedFields) {
+				t.Errorf("Expected fields to be '%v': received '%v'", tc.expectedFields, fields)
+			}
+		})
+	}
+
+	t.Run("should not change the original error", func(t *testing.T) {
+		original := errors.New("some message").WithField("order_id", "123")
+		_ = original.WithField("order_id", "456")
+
+		if fields := errors.Fields(original); fields["order_id"] != "123" {
+			t.Errorf("Expected original field to be kept, received '%v'", fields["order_id"])
+		}
+	})
+
+	t.Run("should keep errors comparable", func(t *testing.T) {
+		var err error = errors.New("some message").WithField("order_id", "123")
+
+		if err == errors.ErrMock {
+			t.Error("Expected errors to be different")
+		}
+	})
+}
+
+func TestIs(t *testing.T) {
+	rootErr := e.New("root error")
+
+	tt := []struct {
+		name     string
+		err      error
+		target   error
+		expected bool
+	}{
+		{
+			name:     "same custom error",
+			err:      errors.ErrResourceNotFound,
+			target:   errors.ErrResourceNotFound,
+			expected: true,
+		},
+		{
+			name:     "custom error with same kind and code",
+			err:      errors.New("order not found").WithKind(errors.KindNotFound).WithCode("RESOURCE_NOT_FOUND"),
+			target:   errors.ErrResourceNotFound,
+			expected: true,
+		},
+		{
+			name:     "custom error with same kind and code wrapping a root error",
+			err:      errors.ErrResourceNotFound.(errors.CustomError).WithRootError(rootErr),
+			target:   errors.ErrResourceNotFound,
+			expected: true,
+		},
+		{
+			name:     "custom error wrapped by native error",
+			err:      fmt.Errorf("wrapped: %w", errors.ErrResourceNotFound),
+			target:   errors.ErrResourceNotFound,
+			expected: true,
+		},
+		{
+			name:     "custom error wrapped as root error of another custom error",
+			err:      errors.New("could not get order").WithKind(errors.KindInternal).WithRootError(errors.ErrResourceNotFound),
+			target:   errors.ErrResourceNotFound,
+			expected: true,
+		},
+		{
+			name:     "native root error of a custom error",
+			err:      errors.New("could not get order").WithRootError(rootErr),
+			target:   rootErr,
+			expected: true,
+		},
+		{
+			name:     "custom error with different kind",
+			err:      errors.New("order not found").WithKind(errors.KindInternal).WithCode("RESOURCE_NOT_FOUND"),
+			target:   errors.ErrResourceNotFound,
+			expected: false,
+		},
+		{
+			name:     "custom error with different code",
+			err:      errors.New("order not found").WithKind(errors.KindNotFound).WithCode("ORDER_NOT_FOUND"),
+			target:   errors.ErrResourceNotFound,
+			expected: false,
+		},
+		{
+			name:     "custom errors without code and different messages",
+			err:      errors.New("some message"),
+			target:   errors.New("another message"),
+			expected: false,
+		},
+		{
+			name:     "custom errors without code and same messages",
+			err:      errors.New("some message"),
+			target:   errors.New("some message"),
+			expected: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if is := e.Is(tc.err, tc.target); is != tc.expected {
+				t.Errorf("Expected errors.Is to be '%v': received '%v'", tc.expected, is)
+			}
+		})
+	}
+
+	t.Run("should find a CustomError wrapped by a native error with errors.As", func(t *testing.T) {
+		var customError errors.CustomError
+		if !e.As(fmt.Errorf("wrapped: %w", errors.ErrResourceNotFound), &customError) {
+			t.Fatal("Expected CustomError to be found")
+		}
+
+		if errors.Code(customError) != "RESOURCE_NOT_FOUND" {
+			t.Errorf("Wrong error code, got: %s", errors.Code(customError))
+		}
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Run("should return nil when there are no errors", func(t *testing.T) {
+		if err := errors.Join(nil, nil); err != nil {
+			t.Errorf("Expected nil, got '%v'", err)
+		}
+	})
+
+	t.Run("should aggregate errors discarding nil ones", func(t *testing.T) {
+		err := errors.Join(e.New("first error"), nil, errors.New("second error"))
+
+		var multiErr *errors.MultiError
+		if !e.As(err, &multiErr) {
+			t.Fatal("Expected a MultiError")
+		}
+
+		if len(multiErr.Errors()) != 2 {
+			t.Errorf("Expected 2 errors, got %d", len(multiErr.Errors()))
+		}
+
+		if err.Error() != "first error; second error" {
+			t.Errorf("Wrong error message, got: %s", err.Error())
+		}
+	})
+
+	t.Run("should flatten nested MultiErrors", func(t *testing.T) {
+		err := errors.Join(errors.Join(e.New("first error"), e.New("second error")), e.New("third error"))
+
+		if n := len(err.(*errors.MultiError).Errors()); n != 3 {
+			t.Errorf("Expected 3 errors, got %d", n)
+		}
+	})
+
+	t.Run("should support errors.Is on aggregated errors", func(t *testing.T) {
+		err := errors.Join(e.New("first error"), fmt.Errorf("wrapped: %w", errors.ErrResourceNotFound))
+
+		if !e.Is(err, errors.ErrResourceNotFound) {
+			t.Error("Expected aggregated error to be found")
+		}
+
+		if e.Is(err, errors.ErrNotImplemented) {
+			t.Error("Expected non aggregated error not to be found")
+		}
+	})
+
+	t.Run("should support errors.As on aggregated errors", func(t *testing.T) {
+		err := errors.Join(e.New("first error"), fmt.Errorf("wrapped: %w", errors.New("second error").WithCode("SECOND")))
+
+		var customErr errors.CustomError
+		if !e.As(err, &customErr) {
+			t.Fatal("Expected aggregated CustomError to be found")
+		}
+
+		if errors.Code(customErr) != "SECOND" {
+			t.Errorf("Wrong error code, got: %s", errors.Code(customErr))
+		}
+	})
+
+	t.Run("should return the aggregated errors of wrapped MultiErrors", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", errors.Join(e.New("first error"), e.New("second error")))
+
+		if n := len(errors.Errors(err)); n != 2 {
+			t.Errorf("Expected 2 errors, got %d", n)
+		}
+
+		if errs := errors.Errors(errors.New("single error")); errs != nil {
+			t.Errorf("Expected nil, got %v", errs)
+		}
+	})
+
+	tt := []struct {
+		name               string
+		err                error
+		expectedKind       errors.KindType
+		expectedCode       errors.CodeType
+		expectedRootErrMsg string
+	}{
+		{
+			name:               "native errors",
+			err:                errors.Join(e.New("first error"), e.New("second error")),
+			expectedKind:       errors.KindUnexpected,
+			expectedCode:       errors.CodeUnknown,
+			expectedRootErrMsg: "first error; second error",
+		},
+		{
+			name: "internal error prevails over client errors",
+			err: errors.Join(
+				errors.New("invalid input").WithKind(errors.KindInvalidInput).WithCode("INVALID"),
+				errors.New("internal").WithKind(errors.KindInternal).WithCode("INTERNAL").WithRootError(e.New("root error")),
+				errors.New("not found").WithKind(errors.KindNotFound).WithCode("NOT_FOUND"),
+			),
+			expectedKind:       errors.KindInternal,
+			expectedCode:       "INTERNAL",
+			expectedRootErrMsg: "invalid input; root error; not found",
+		},
+		{
+			name: "not found prevails over invalid input",
+			err: errors.Join(
+				errors.New("invalid input").WithKind(errors.KindInvalidInput).WithCode("INVALID"),
+				errors.New("not found").WithKind(errors.KindNotFound).WithCode("NOT_FOUND"),
+			),
+			expectedKind:       errors.KindNotFound,
+			expectedCode:       "NOT_FOUND",
+			expectedRootErrMsg: "invalid input; not found",
+		},
+		{
+			name: "first error prevails on same kind",
+			err: errors.Join(
+				errors.New("first").WithKind(errors.KindInvalidInput).WithCode("FIRST"),
+				errors.New("second").WithKind(errors.KindInvalidInput).WithCode("SECOND"),
+			),
+			expectedKind:       errors.KindInvalidInput,
+			expectedCode:       "FIRST",
+			expectedRootErrMsg: "first; second",
+		},
+		{
+			name: "custom error wrapping a MultiError",
+			err: errors.New("invalid request").WithKind(errors.KindInvalidInput).WithCode("INVALID_REQUEST").WithRootError(
+				errors.Join(errors.New("internal").WithKind(errors.KindInternal)),
+			),
+			expectedKind:       errors.KindInvalidInput,
+			expectedCode:       "INVALID_REQUEST",
+			expectedRootErrMsg: "internal",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if kind := errors.Kind(tc.err); kind != tc.expectedKind {
+				t.Errorf("Expected kind to be '%s': received '%s'", tc.expectedKind, kind)
+			}
+
+			if code := errors.Code(tc.err); code != tc.expectedCode {
+				t.Errorf("Expected code to be '%s': received '%s'", tc.expectedCode, code)
+			}
+
+			if rootErr := errors.RootError(tc.err); rootErr != tc.expectedRootErrMsg {
+				t.Errorf("Expected root error to be '%s': received '%s'", tc.expectedRootErrMsg, rootErr)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("should build a CustomError from the registered definition", func(t *testing.T) {
+		registry := errors.NewRegistry()
+
+		err, regErr := registry.Register(errors.CodeDefinition{Code: "ORDER_NOT_FOUND", Kind: errors.KindNotFound, Message: "order 100% not found"})
+		if regErr != nil {
+			t.Fatalf("Unexpected error: %v", regErr)
+		}
+
+		if err.Error() != "order 100% not found" {
+			t.Errorf("Wrong error message, got: %s", err.Error())
+		}
+
+		if errors.Kind(err) != errors.KindNotFound {
+			t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+		}
+
+		if errors.Code(err) != "ORDER_NOT_FOUND" {
+			t.Errorf("Wrong error code, got: %s", errors.Code(err))
+		}
+	})
+
+	t.Run("should fail when registering the same code twice", func(t *testing.T) {
+		registry := errors.NewRegistry()
+		registry.MustRegister(errors.CodeDefinition{Code: "ORDER_NOT_FOUND", Kind: errors.KindNotFound})
+
+		_, err := registry.Register(errors.CodeDefinition{Code: "ORDER_NOT_FOUND", Kind: errors.KindInternal})
+		if errors.Code(err) != "DUPLICATED_ERROR_CODE" {
+			t.Errorf("Wrong error code, got: %s", errors.Code(err))
+		}
+
+		if def, _ := registry.Lookup("ORDER_NOT_FOUND"); def.Kind != errors.KindNotFound {
+			t.Errorf("Expected first definition to be kept, got: %v", def)
+		}
+	})
+
+	t.Run("should panic when registering the same code twice with MustRegister", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected panic")
+			}
+		}()
+
+		errors.MustRegister(errors.CodeDefinition{Code: "RESOURCE_NOT_FOUND"})
+	})
+
+	t.Run("should fail when code is not informed", func(t *testing.T) {
+		if _, err := errors.NewRegistry().Register(errors.CodeDefinition{}); errors.Kind(err) != errors.KindInvalidInput {
+			t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+		}
+	})
+
+	t.Run("should export definitions sorted by code", func(t *testing.T) {
+		registry := errors.NewRegistry()
+		registry.MustRegister(errors.CodeDefinition{Code: "B_CODE", Kind: errors.KindConflict, Message: "b message", Description: "b | description"})
+		registry.MustRegister(errors.CodeDefinition{Code: "A_CODE", Message: "a message"})
+
+		expectedJSON := `[
+  {
+    "code": "A_CODE",
+    "kind": "UNEXPECTED",
+    "message": "a message"
+  },
+  {
+    "code": "B_CODE",
+    "kind": "CONFLICT",
+    "message": "b message",
+    "description": "b | description"
+  }
+]`
+		if data, _ := registry.ExportJSON(); string(data) != expectedJSON {
+			t.Errorf("Wrong JSON export, got: %s", data)
+		}
+
+		expectedMarkdown := "| Code | Kind | Message | Description |\n" +
+			"| ---- | ---- | ------- | ----------- |\n" +
+			"| `A_CODE` | `UNEXPECTED` | a message |  |\n" +
+			"| `B_CODE` | `CONFLICT` | b message | b \\| description |\n"
+		if markdown := registry.ExportMarkdown(); markdown != expectedMarkdown {
+			t.Errorf("Wrong Markdown export, got: %s", markdown)
+		}
+	})
+
+	t.Run("should register package errors in the default registry", func(t *testing.T) {
+		if _, ok := errors.Lookup("RESOURCE_NOT_FOUND"); !ok {
+			t.Error("Expected RESOURCE_NOT_FOUND to be registered")
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	tt := []struct {
+		name               string
+		err                error
+		expectedRetryable  bool
+		expectedRetryAfter time.Duration
+	}{
+		{
+			name:              "go native error",
+			err:               e.New("new error"),
+			expectedRetryable: false,
+		},
+		{
+			name:              "custom error not marked",
+			err:               errors.New("some message"),
+			expectedRetryable: false,
+		},
+		{
+			name:              "custom error marked as retryable",
+			err:               errors.New("some message").WithRetryable(true),
+			expectedRetryable: true,
+		},
+		{
+			name:               "custom error with retry after",
+			err:                errors.New("some message").WithRetryAfter(time.Second),
+			expectedRetryable:  true,
+			expectedRetryAfter: time.Second,
+		},
+		{
+			name:               "custom error wrapping a retryable error",
+			err:                fmt.Errorf("wrapped: %w", errors.New("head error").WithRootError(errors.New("tail error").WithRetryAfter(time.Minute))),
+			expectedRetryable:  true,
+			expectedRetryAfter: time.Minute,
+		},
+		{
+			name:              "custom error marked as not retryable wrapping a retryable error",
+			err:               errors.New("head error").WithRetryable(false).WithRootError(errors.New("tail error").WithRetryAfter(time.Minute)),
+			expectedRetryable: false,
+		},
+		{
+			name: "multi error",
+			err: errors.Join(
+				errors.New("invalid input").WithKind(errors.KindInvalidInput),
+				errors.New("internal").WithKind(errors.KindInternal).WithRetryAfter(time.Second),
+			),
+			expectedRetryable:  true,
+			expectedRetryAfter: time.Second,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if retryable := errors.IsRetryable(tc.err); retryable != tc.expectedRetryable {
+				t.Errorf("Expected retryable to be '%v': received '%v'", tc.expectedRetryable, retryable)
+			}
+
+			after, ok := errors.RetryAfter(tc.err)
+			if after != tc.expectedRetryAfter || ok != (tc.expectedRetryAfter > 0) {
+				t.Errorf("Expected retry after to be '%v': received '%v'", tc.expectedRetryAfter, after)
+			}
+		})
+	}
+}
+
+func TestPublicMessage(t *testing.T) {
+	tt := []struct {
+		name            string
+		err             error
+		expectedMessage string
+	}{
+		{
+			name:            "go native error",
+			err:             e.New("pq: connection refused"),
+			expectedMessage: errors.DefaultPublicMessage,
+		},
+		{
+			name:            "unexpected custom error",
+			err:             errors.New("could not query orders"),
+			expectedMessage: errors.DefaultPublicMessage,
+		},
+		{
+			name:            "internal custom error without message",
+			err:             errors.New("").WithKind(errors.KindInternal).WithRootError(e.New("pq: connection refused")),
+			expectedMessage: errors.DefaultPublicMessage,
+		},
+		{
+			name:            "internal custom error with public message",
+			err:             errors.New("could not query orders").WithKind(errors.KindInternal).WithPublicMessage("orders are unavailable"),
+			expectedMessage: "orders are unavailable",
+		},
+		{
+			name:            "client custom error",
+			err:             errors.New("order not found").WithKind(errors.KindNotFound).WithRootError(e.New("sql: no rows in result set")),
+			expectedMessage: "order not found",
+		},
+		{
+			name:            "client custom error without message",
+			err:             errors.New("").WithKind(errors.KindNotFound).WithRootError(e.New("sql: no rows in result set")),
+			expectedMessage: "not found",
+		},
+		{
+			name:            "public message in the root error chain",
+			err:             fmt.Errorf("wrapped: %w", errors.New("head error").WithRootError(errors.New("tail error").WithPublicMessage("try again later"))),
+			expectedMessage: "try again later",
+		},
+		{
+			name: "multi error",
+			err: errors.Join(
+				errors.New("invalid name").WithKind(errors.KindInvalidInput),
+				errors.New("could not query orders").WithKind(errors.KindInternal),
+			),
+			expectedMessage: "invalid name; " + errors.DefaultPublicMessage,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if msg := errors.PublicMessage(tc.err); msg != tc.expectedMessage {
+				t.Errorf("Expected public message to be '%s': received '%s'", tc.expectedMessage, msg)
+			}
+		})
+	}
+
+	t.Run("should keep the internal message", func(t *testing.T) {
+		err := errors.New("could not query orders").WithPublicMessage("orders are unavailable")
+
+		if err.Error() != "could not query orders" {
+			t.Errorf("Wrong error message, got: %s", err.Error())
+		}
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("should encode and decode a CustomError", func(t *testing.T) {
+		violation := errors.FieldViolation{Field: "name", Rule: "required", Message: "name is required"}
+		original := errors.New("could not create order").
+			WithKind(errors.KindConflict).
+			WithCode("ORDER_ALREADY_EXISTS").
+			WithPublicMessage("order already exists").
+			WithField("order_id", "123").
+			WithViolations(violation).
+			WithRetryAfter(time.Minute).
+			WithRootError(errors.New("duplicated key").WithKind(errors.KindInternal).WithRootError(e.New("pq: unique violation")))
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded errors.CustomError
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if decoded.Error() != original.Error() {
+			t.Errorf("Wrong error message, got: %s", decoded.Error())
+		}
+
+		if errors.Kind(decoded) != errors.KindConflict || errors.Code(decoded) != "ORDER_ALREADY_EXISTS" {
+			t.Errorf("Wrong error kind and code, got: %s and %s", errors.Kind(decoded), errors.Code(decoded))
+		}
+
+		if errors.PublicMessage(decoded) != "order already exists" {
+			t.Errorf("Wrong public message, got: %s", errors.PublicMessage(decoded))
+		}
+
+		if fields := errors.Fields(decoded); fields["order_id"] != "123" {
+			t.Errorf("Wrong fields, got: %v", fields)
+		}
+
+		if violations := errors.Violations(decoded); !reflect.DeepEqual(violations, []errors.FieldViolation{violation}) {
+			t.Errorf("Wrong violations, got: %v", violations)
+		}
+
+		if after, _ := errors.RetryAfter(decoded); after != time.Minute {
+			t.Errorf("Wrong retry after, got: %v", after)
+		}
+
+		if errors.RootError(decoded) != "pq: unique violation" {
+			t.Errorf("Wrong root error, got: %s", errors.RootError(decoded))
+		}
+
+		if errors.Kind(e.Unwrap(decoded)) != errors.KindInternal {
+			t.Errorf("Wrong root error kind, got: %s", errors.Kind(e.Unwrap(decoded)))
+		}
+	})
+
+	t.Run("should decode an error payload written by HTTP error handlers", func(t *testing.T) {
+		var decoded errors.CustomError
+		if err := json.Unmarshal([]byte(`{"code":"RESOURCE_NOT_FOUND","message":"resource not found","details":{"order_id":"123"}}`), &decoded); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if errors.Code(decoded) != "RESOURCE_NOT_FOUND" || errors.Kind(decoded) != errors.KindUnexpected {
+			t.Errorf("Wrong error kind and code, got: %s and %s", errors.Kind(decoded), errors.Code(decoded))
+		}
+
+		if decoded.Error() != "resource not found" {
+			t.Errorf("Wrong error message, got: %s", decoded.Error())
+		}
+	})
+
+	t.Run("should fail to decode an invalid retry after", func(t *testing.T) {
+		var decoded errors.CustomError
+		if err := json.Unmarshal([]byte(`{"message":"some message","retry_after":"soon"}`), &decoded); err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
+
+func TestStatusMapping(t *testing.T) {
+	t.Cleanup(errors.ResetStatusMappings())
+
+	errors.MustRegisterStatusMapping(errors.StatusMapping{Kind: "MAPPED_KIND", HTTPStatus: 422, GRPCStatus: 9})
+	errors.MustRegisterStatusMapping(errors.StatusMapping{Kind: "MAPPED_KIND", Code: "MAPPED_CODE", HTTPStatus: 418})
+	errors.MustRegisterStatusMapping(errors.StatusMapping{Code: "ANY_KIND_MAPPED_CODE", GRPCStatus: 10})
+
+	tt := []struct {
+		name               string
+		err                error
+		expectedHTTPStatus int
+		expectedGRPCStatus uint32
+	}{
+		{
+			name: "go native error",
+			err:  e.New("new error"),
+		},
+		{
+			name:               "custom error with mapped kind",
+			err:                errors.New("some message").WithKind("MAPPED_KIND"),
+			expectedHTTPStatus: 422,
+			expectedGRPCStatus: 9,
+		},
+		{
+			name:               "custom error with mapped kind and code",
+			err:                errors.New("some message").WithKind("MAPPED_KIND").WithCode("MAPPED_CODE"),
+			expectedHTTPStatus: 418,
+			expectedGRPCStatus: 9,
+		},
+		{
+			name:               "custom error with mapped code",
+			err:                errors.New("some message").WithKind(errors.KindConflict).WithCode("ANY_KIND_MAPPED_CODE"),
+			expectedGRPCStatus: 10,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			httpStatus, ok := errors.HTTPStatus(tc.err)
+			if httpStatus != tc.expectedHTTPStatus || ok != (tc.expectedHTTPStatus != 0) {
+				t.Errorf("Expected HTTP status to be '%d': received '%d'", tc.expectedHTTPStatus, httpStatus)
+			}
+
+			grpcStatus, ok := errors.GRPCStatus(tc.err)
+			if grpcStatus != tc.expectedGRPCStatus || ok != (tc.expectedGRPCStatus != 0) {
+				t.Errorf("Expected gRPC status to be '%d': received '%d'", tc.expectedGRPCStatus, grpcStatus)
+			}
+		})
+	}
+
+	t.Run("should find the kind mapped into an HTTP status", func(t *testing.T) {
+		kindTT := []struct {
+			status       int
+			code         errors.CodeType
+			expectedKind errors.KindType
+		}{
+			{status: 422, expectedKind: "MAPPED_KIND"},
+			{status: 422, code: "UNMAPPED_CODE", expectedKind: "MAPPED_KIND"},
+			{status: 418, code: "MAPPED_CODE", expectedKind: "MAPPED_KIND"},
+			{status: 418},
+			{status: 400},
+		}
+
+		for _, tc := range kindTT {
+			kind, ok := errors.HTTPStatusKind(tc.status, tc.code)
+			if kind != tc.expectedKind || ok != (tc.expectedKind != "") {
+				t.Errorf("Expected kind of status '%d' and code '%s' to be '%s': received '%s'", tc.status, tc.code, tc.expectedKind, kind)
+			}
+		}
+	})
+
+	t.Run("should fail when registering the same mapping twice", func(t *testing.T) {
+		err := errors.RegisterStatusMapping(errors.StatusMapping{Kind: "MAPPED_KIND", HTTPStatus: 400})
+		if errors.Code(err) != "DUPLICATED_STATUS_MAPPING" {
+			t.Errorf("Wrong error code, got: %s", errors.Code(err))
+		}
+	})
+
+	t.Run("should fail when neither kind nor code are informed", func(t *testing.T) {
+		if err := errors.RegisterStatusMapping(errors.StatusMapping{HTTPStatus: 400}); errors.Kind(err) != errors.KindInvalidInput {
+			t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+		}
+	})
+}
+
+func TestFromPanic(t *testing.T) {
+	recoverPanic := func(f func()) (err errors.CustomError) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.FromPanic(r)
+			}
+		}()
+		f()
+		return
+	}
+
+	panickingFunc := func() {
+		panic("mocked panic")
+	}
+
+	t.Run("should build an internal error with the panic stack", func(t *testing.T) {
+		err := recoverPanic(panickingFunc)
+
+		if !e.Is(err, errors.ErrPanicRecovered) {
+			t.Errorf("expected error to match ErrPanicRecovered, got %v", err)
+		}
+
+		if errors.Kind(err) != errors.KindInternal {
+			t.Errorf("Wrong error kind, got: %s", errors.Kind(err))
+		}
+
+		if err.Error() != "panic recovered: mocked panic" {
+			t.Errorf("Wrong error message, got: %s", err.Error())
+		}
+
+		found := false
+		for _, frame := range errors.Stack(err) {
+			if strings.HasSuffix(frame.Function, "TestFromPanic.func2") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected stack trace to include the function that panicked, got:\n%s", errors.Stack(err))
+		}
+
+		if stack := errors.Stack(err); len(stack) == 0 || stack[0].Function != "runtime.gopanic" {
+			t.Errorf("expected stack trace to start at the runtime panic, got:\n%s", stack)
+		}
+	})
+
+	t.Run("should attach the panic error as root error", func(t *testing.T) {
+		err := recoverPanic(func() { panic(errors.ErrMock) })
+
+		if !e.Is(err, errors.ErrMock) {
+			t.Errorf("expected error to wrap the panic error, got %v", err)
+		}
+	})
+}
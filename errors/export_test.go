@@ -0,0 +1,4 @@
+package errors
+
+// ResetStatusMappings exposes resetStatusMappings to external tests.
+var ResetStatusMappings = resetStatusMappings
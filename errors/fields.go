@@ -0,0 +1,48 @@
+package errors
+
+import e "errors"
+
+// fieldSet holds the key/value details attached into a CustomError.
+type fieldSet map[string]any
+
+// WithField returns a copy of the CustomError with the given key/value detail attached.
+// If the key already exists, its value is overwritten.
+func (ce CustomError) WithField(key string, value any) CustomError {
+	return ce.WithFields(map[string]any{key: value})
+}
+
+// WithFields returns a copy of the CustomError with the given key/value details attached.
+// Existing keys have their values overwritten.
+func (ce CustomError) WithFields(fields map[string]any) CustomError {
+	merged := fieldSet{}
+	if ce.fields != nil {
+		for key, value := range *ce.fields {
+			merged[key] = value
+		}
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	ce.fields = &merged
+	return ce
+}
+
+// Fields tries to convert the given error into a CustomError.
+// If so, it returns the key/value details attached along its RootError chain.
+// When the same key is found more than once, the value of the outermost error prevails.
+func Fields(err error) map[string]any {
+	var customError CustomError
+	if !e.As(err, &customError) {
+		return map[string]any{}
+	}
+
+	fields := Fields(customError.rootErr)
+	if customError.fields != nil {
+		for key, value := range *customError.fields {
+			fields[key] = value
+		}
+	}
+
+	return fields
+}
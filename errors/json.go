@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"encoding/json"
+	e "errors"
+	"time"
+)
+
+// customErrorJSON is the JSON representation of a CustomError.
+// Its attributes are a superset of the error payload written by HTTP error handlers, so both can be decoded the same way.
+type customErrorJSON struct {
+	Kind          KindType         `json:"kind,omitempty"`
+	Code          CodeType         `json:"code,omitempty"`
+	Message       string           `json:"message"`
+	PublicMessage string           `json:"public_message,omitempty"`
+	Details       map[string]any   `json:"details,omitempty"`
+	Violations    []FieldViolation `json:"violations,omitempty"`
+	Retryable     *bool            `json:"retryable,omitempty"`
+	RetryAfter    string           `json:"retry_after,omitempty"`
+	RootError     *customErrorJSON `json:"root_error,omitempty"`
+}
+
+// MarshalJSON encodes the CustomError and its RootError chain as JSON, so it can be sent across service boundaries.
+// Root errors that are not CustomErrors are encoded with their messages only. Call stacks are not encoded.
+func (ce CustomError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ce.toJSON())
+}
+
+// UnmarshalJSON decodes a CustomError encoded by MarshalJSON.
+// Missing kinds and codes are filled with KindUnexpected and CodeUnknown, respectively.
+func (ce *CustomError) UnmarshalJSON(data []byte) error {
+	var payload customErrorJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	decoded, err := payload.toCustomError()
+	if err != nil {
+		return err
+	}
+
+	*ce = decoded
+	return nil
+}
+
+func (ce CustomError) toJSON() *customErrorJSON {
+	payload := &customErrorJSON{
+		Kind:          ce.kind,
+		Code:          ce.code,
+		Message:       ce.message,
+		PublicMessage: ce.publicMessage,
+	}
+
+	if ce.fields != nil {
+		payload.Details = *ce.fields
+	}
+
+	if ce.violations != nil {
+		payload.Violations = *ce.violations
+	}
+
+	if ce.retry != retryUnset {
+		retryable := ce.retry == retryEnabled
+		payload.Retryable = &retryable
+	}
+
+	if ce.retryAfter > 0 {
+		payload.RetryAfter = ce.retryAfter.String()
+	}
+
+	var customError CustomError
+	switch {
+	case ce.rootErr == nil:
+	case e.As(ce.rootErr, &customError):
+		payload.RootError = customError.toJSON()
+	default:
+		payload.RootError = &customErrorJSON{Message: ce.rootErr.Error()}
+	}
+
+	return payload
+}
+
+func (p customErrorJSON) toCustomError() (CustomError, error) {
+	ce := CustomError{
+		kind:          p.Kind,
+		code:          p.Code,
+		message:       p.Message,
+		publicMessage: p.PublicMessage,
+	}
+
+	if ce.kind == "" {
+		ce.kind = KindUnexpected
+	}
+
+	if ce.code == "" {
+		ce.code = CodeUnknown
+	}
+
+	if len(p.Details) > 0 {
+		ce = ce.WithFields(p.Details)
+	}
+
+	ce = ce.WithViolations(p.Violations...)
+
+	if p.Retryable != nil {
+		ce = ce.WithRetryable(*p.Retryable)
+	}
+
+	if p.RetryAfter != "" {
+		after, err := time.ParseDuration(p.RetryAfter)
+		if err != nil {
+			return CustomError{}, err
+		}
+		ce = ce.WithRetryAfter(after)
+	}
+
+	if p.RootError != nil {
+		rootErr, err := p.RootError.toCustomError()
+		if err != nil {
+			return CustomError{}, err
+		}
+		ce.rootErr = rootErr
+	}
+
+	return ce, nil
+}
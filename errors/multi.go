@@ -0,0 +1,140 @@
+package errors
+
+import (
+	e "errors"
+	"strings"
+)
+
+// kindPrecedence defines which KindType prevails when multiple errors are aggregated, from the highest to the lowest precedence.
+// Failures of the system itself (or its dependencies) come first, since they are the most relevant to the caller, followed by access and input issues.
+// Kinds not listed here have the lowest precedence.
+var kindPrecedence = []KindType{
+	KindInternal,
+	KindUnexpected,
+	KindUnavailable,
+	KindTimeout,
+	KindNotImplemented,
+	KindCanceled,
+	KindUnauthenticated,
+	KindUnauthorized,
+	KindResourceExhausted,
+	KindNotFound,
+	KindFailedPrecondition,
+	KindConflict,
+	KindInvalidInput,
+}
+
+func kindRank(kind KindType) int {
+	for rank, k := range kindPrecedence {
+		if k == kind {
+			return rank
+		}
+	}
+	return len(kindPrecedence)
+}
+
+// MultiError is an error that aggregates several errors.
+// Its Kind and Code are the ones of the aggregated error with the highest Kind precedence.
+type MultiError struct {
+	errs []error
+}
+
+// Join returns an error that aggregates the given errors.
+// Nil errors are discarded and nested MultiErrors are flattened.
+// It returns nil if there are no errors to aggregate.
+func Join(errs ...error) error {
+	multiErr := &MultiError{}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if nested, ok := err.(*MultiError); ok {
+			multiErr.errs = append(multiErr.errs, nested.errs...)
+			continue
+		}
+
+		multiErr.errs = append(multiErr.errs, err)
+	}
+
+	if len(multiErr.errs) == 0 {
+		return nil
+	}
+
+	return multiErr
+}
+
+// Error returns the messages of all aggregated errors.
+func (me *MultiError) Error() string {
+	msgs := make([]string, 0, len(me.errs))
+	for _, err := range me.errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Errors returns a copy of the aggregated errors.
+func (me *MultiError) Errors() []error {
+	return append([]error{}, me.errs...)
+}
+
+// Unwrap returns the aggregated errors.
+// It allows the standard library errors.Is and errors.As to inspect all of them, since Go 1.20.
+func (me *MultiError) Unwrap() []error {
+	return me.Errors()
+}
+
+// Is reports whether any aggregated error matches the target error.
+// It allows the standard library errors.Is to inspect all aggregated errors in Go versions without multi-error unwrapping.
+func (me *MultiError) Is(target error) bool {
+	for _, err := range me.errs {
+		if e.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first aggregated error that matches the target, and if so, sets target to that error value.
+// It allows the standard library errors.As to inspect all aggregated errors in Go versions without multi-error unwrapping.
+func (me *MultiError) As(target any) bool {
+	for _, err := range me.errs {
+		if e.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the errors aggregated by the outermost MultiError in the error chain.
+// It returns nil when the outermost CustomError or MultiError of the chain is not a MultiError.
+func Errors(err error) []error {
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		return multiErr.Errors()
+	}
+	return nil
+}
+
+// prevailing returns the aggregated error with the highest Kind precedence.
+// When more than one error has the same Kind, the first one prevails.
+func (me *MultiError) prevailing() error {
+	var prevailing error
+	for _, err := range me.errs {
+		if prevailing == nil || kindRank(Kind(err)) < kindRank(Kind(prevailing)) {
+			prevailing = err
+		}
+	}
+	return prevailing
+}
+
+// outermost looks for the first CustomError or MultiError in the given error chain.
+func outermost(err error) error {
+	for err != nil {
+		switch err.(type) {
+		case CustomError, *MultiError:
+			return err
+		}
+		err = e.Unwrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package errors
+
+// ErrPanicRecovered indicates that a panic was recovered while handling a request.
+var ErrPanicRecovered error = MustRegister(CodeDefinition{
+	Code:        "PANIC_RECOVERED",
+	Kind:        KindInternal,
+	Message:     "panic recovered",
+	Description: "The application panicked while handling the request.",
+})
+
+// FromPanic builds a CustomError of KindInternal from a recovered panic value, with the call stack of the panic attached.
+// It must be called by the deferred function that recovered the panic.
+// If the panic value is an error, it is attached as the RootError.
+func FromPanic(recovered any) CustomError {
+	err := New("panic recovered: %v", recovered).WithKind(KindInternal).WithCode(Code(ErrPanicRecovered))
+	if rootErr, ok := recovered.(error); ok {
+		err = err.WithRootError(rootErr)
+	}
+
+	// Skips FromPanic and the deferred function that called it, so the stack starts at the runtime panic, followed by the function that panicked.
+	err.stack = callers(3)
+	return err
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	e "errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultPublicMessage is the message exposed to clients when an internal failure has no public message attached.
+const DefaultPublicMessage = "an internal error has occurred"
+
+// internalKinds are the kinds of failures whose messages are never exposed to clients, since they may contain internal details.
+var internalKinds = map[KindType]bool{
+	KindInternal:   true,
+	KindUnexpected: true,
+}
+
+// WithPublicMessage returns a copy of the CustomError with the given user-facing message filled.
+// It's the only message exposed to clients, while the internal message is kept for diagnostic purposes, like logs.
+func (ce CustomError) WithPublicMessage(message string, args ...interface{}) CustomError {
+	ce.publicMessage = fmt.Sprintf(message, args...)
+	return ce
+}
+
+// PublicMessage returns the message of the given error that is safe to be exposed to clients.
+// It returns the public message of the outermost CustomError of its RootError chain that has one.
+// Otherwise, internal failures (KindInternal and KindUnexpected) fall back to DefaultPublicMessage,
+// while any other failure falls back to the message of its outermost CustomError, without its RootError.
+// For MultiErrors, the public messages of all aggregated errors are returned.
+func PublicMessage(err error) string {
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		msgs := make([]string, 0, len(multiErr.errs))
+		for _, err := range multiErr.errs {
+			msgs = append(msgs, PublicMessage(err))
+		}
+		return strings.Join(msgs, "; ")
+	}
+
+	if msg := publicMessage(err); msg != "" {
+		return msg
+	}
+
+	var customError CustomError
+	if internalKinds[Kind(err)] || !e.As(err, &customError) {
+		return DefaultPublicMessage
+	}
+
+	if customError.message != "" {
+		return customError.message
+	}
+
+	return strings.ToLower(strings.ReplaceAll(string(customError.kind), "_", " "))
+}
+
+func publicMessage(err error) string {
+	var customError CustomError
+	if !e.As(err, &customError) {
+		return ""
+	}
+
+	if customError.publicMessage != "" {
+		return customError.publicMessage
+	}
+
+	return publicMessage(customError.rootErr)
+}
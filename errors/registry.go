@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CodeDefinition documents the meaning of an error code.
+type CodeDefinition struct {
+	Code        CodeType `json:"code"`
+	Kind        KindType `json:"kind"`
+	Message     string   `json:"message"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Registry is a catalog of error codes. It guarantees each code has a single meaning.
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[CodeType]CodeDefinition
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: map[CodeType]CodeDefinition{},
+	}
+}
+
+// Register declares a new error code, and returns a CustomError built from its definition.
+// It fails if the code is empty or if it was already registered.
+func (r *Registry) Register(def CodeDefinition) (CustomError, error) {
+	if def.Code == "" || def.Code == CodeUnknown {
+		return CustomError{}, NewValidationError("error code must be informed")
+	}
+
+	if def.Kind == "" {
+		def.Kind = KindUnexpected
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.definitions[def.Code]; ok {
+		return CustomError{}, New("error code %s is already registered", def.Code).WithKind(KindConflict).WithCode("DUPLICATED_ERROR_CODE")
+	}
+
+	r.definitions[def.Code] = def
+
+	return CustomError{
+		kind:    def.Kind,
+		code:    def.Code,
+		message: def.Message,
+	}, nil
+}
+
+// MustRegister declares a new error code, and returns a CustomError built from its definition.
+// It panics if the code can't be registered, so duplicated codes are found as soon as the application starts.
+func (r *Registry) MustRegister(def CodeDefinition) CustomError {
+	ce, err := r.Register(def)
+	if err != nil {
+		panic(err)
+	}
+
+	return ce
+}
+
+// Lookup returns the definition of the given error code, if registered.
+func (r *Registry) Lookup(code CodeType) (CodeDefinition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.definitions[code]
+	return def, ok
+}
+
+// Definitions returns all registered definitions, sorted by code.
+func (r *Registry) Definitions() []CodeDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]CodeDefinition, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		defs = append(defs, def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Code < defs[j].Code
+	})
+
+	return defs
+}
+
+// ExportJSON exports all registered definitions as a JSON array, sorted by code.
+func (r *Registry) ExportJSON() ([]byte, error) {
+	return json.MarshalIndent(r.Definitions(), "", "  ")
+}
+
+// ExportMarkdown exports all registered definitions as a Markdown table, sorted by code.
+func (r *Registry) ExportMarkdown() string {
+	var sb strings.Builder
+	sb.WriteString("| Code | Kind | Message | Description |\n")
+	sb.WriteString("| ---- | ---- | ------- | ----------- |\n")
+
+	for _, def := range r.Definitions() {
+		sb.WriteString(fmt.Sprintf(
+			"| `%s` | `%s` | %s | %s |\n",
+			def.Code,
+			def.Kind,
+			EscapeMarkdownCell(def.Message),
+			EscapeMarkdownCell(def.Description),
+		))
+	}
+
+	return sb.String()
+}
+
+// EscapeMarkdownCell escapes the given text to be written into a Markdown table cell, as ExportMarkdown does:
+// pipes are escaped and line breaks are replaced by spaces, so they don't break the table.
+func EscapeMarkdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// defaultRegistry is the Registry used by the package level functions.
+var defaultRegistry = NewRegistry()
+
+// Register declares a new error code in the default Registry, and returns a CustomError built from its definition.
+// It fails if the code is empty or if it was already registered.
+func Register(def CodeDefinition) (CustomError, error) {
+	return defaultRegistry.Register(def)
+}
+
+// MustRegister declares a new error code in the default Registry, and returns a CustomError built from its definition.
+// It panics if the code can't be registered. It's supposed to be used when declaring package level errors:
+//
+//	var ErrOrderNotFound = errors.MustRegister(errors.CodeDefinition{
+//		Code:        "ORDER_NOT_FOUND",
+//		Kind:        errors.KindNotFound,
+//		Message:     "order not found",
+//		Description: "The requested order does not exist or was deleted.",
+//	})
+func MustRegister(def CodeDefinition) CustomError {
+	return defaultRegistry.MustRegister(def)
+}
+
+// Lookup returns the definition of the given error code in the default Registry, if registered.
+func Lookup(code CodeType) (CodeDefinition, bool) {
+	return defaultRegistry.Lookup(code)
+}
+
+// Definitions returns all definitions of the default Registry, sorted by code.
+func Definitions() []CodeDefinition {
+	return defaultRegistry.Definitions()
+}
+
+// ExportJSON exports all definitions of the default Registry as a JSON array, sorted by code.
+func ExportJSON() ([]byte, error) {
+	return defaultRegistry.ExportJSON()
+}
+
+// ExportMarkdown exports all definitions of the default Registry as a Markdown table, sorted by code.
+func ExportMarkdown() string {
+	return defaultRegistry.ExportMarkdown()
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	e "errors"
+	"time"
+)
+
+// retryPolicy indicates whether a failure is transient.
+type retryPolicy int8
+
+const (
+	retryUnset retryPolicy = iota
+	retryEnabled
+	retryDisabled
+)
+
+// WithRetryable returns a copy of the CustomError marked as retryable (or not), indicating whether the failure is transient.
+func (ce CustomError) WithRetryable(retryable bool) CustomError {
+	ce.retry = retryDisabled
+	if retryable {
+		ce.retry = retryEnabled
+	}
+	return ce
+}
+
+// WithRetryAfter returns a copy of the CustomError marked as retryable, with a hint of how long callers should wait before retrying.
+func (ce CustomError) WithRetryAfter(d time.Duration) CustomError {
+	ce.retry = retryEnabled
+	ce.retryAfter = d
+	return ce
+}
+
+// IsRetryable tries to convert the given error into a CustomError.
+// If so, it reports whether the outermost CustomError of its RootError chain marked as retryable (or not) is retryable.
+// For MultiErrors, it considers the aggregated error with the highest Kind precedence.
+func IsRetryable(err error) bool {
+	ce, ok := retryDecision(err)
+	return ok && ce.retry == retryEnabled
+}
+
+// RetryAfter tries to convert the given error into a CustomError.
+// If so, it returns how long callers should wait before retrying, when the error is retryable and the hint was informed.
+func RetryAfter(err error) (time.Duration, bool) {
+	ce, ok := retryDecision(err)
+	if !ok || ce.retry != retryEnabled || ce.retryAfter <= 0 {
+		return 0, false
+	}
+	return ce.retryAfter, true
+}
+
+// retryDecision looks for the outermost CustomError that was explicitly marked as retryable (or not).
+func retryDecision(err error) (CustomError, bool) {
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		return retryDecision(multiErr.prevailing())
+	}
+
+	var customError CustomError
+	if !e.As(err, &customError) {
+		return CustomError{}, false
+	}
+
+	if customError.retry != retryUnset {
+		return customError, true
+	}
+
+	return retryDecision(customError.rootErr)
+}
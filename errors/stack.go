@@ -0,0 +1,106 @@
+package errors
+
+import (
+	e "errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const maxStackDepth = 32
+
+var stackTraceEnabled int32
+
+// SetStackTraceEnabled turns on/off the automatic call stack capture when CustomErrors are built.
+// It's disabled by default. Use WithStackTrace to capture the stack of a single error regardless of this setting.
+func SetStackTraceEnabled(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&stackTraceEnabled, value)
+}
+
+func isStackTraceEnabled() bool {
+	return atomic.LoadInt32(&stackTraceEnabled) == 1
+}
+
+// Frame is a single step of a call stack.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String returns the Frame in the same format used by Go runtime stack traces.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s()\n\t%s:%d", f.Function, f.File, f.Line)
+}
+
+// StackTrace is a call stack, ordered from the innermost to the outermost call.
+type StackTrace []Frame
+
+// String returns the StackTrace in the same format used by Go runtime stack traces.
+func (s StackTrace) String() string {
+	frames := make([]string, 0, len(s))
+	for _, frame := range s {
+		frames = append(frames, frame.String())
+	}
+	return strings.Join(frames, "\n")
+}
+
+// stack holds the raw program counters of a call stack.
+// It is resolved into Frames only when needed, since capturing must be cheap.
+type stack []uintptr
+
+func callers(skip int) *stack {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+1, pcs)
+	s := stack(pcs[:n])
+	return &s
+}
+
+func (s stack) frames() StackTrace {
+	frames := StackTrace{}
+	it := runtime.CallersFrames(s)
+	for {
+		frame, more := it.Next()
+		frames = append(frames, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// WithStackTrace returns a copy of the CustomError with the current call stack attached.
+func (ce CustomError) WithStackTrace() CustomError {
+	ce.stack = callers(2)
+	return ce
+}
+
+// Stack tries to convert the given error into a CustomError.
+// If so, it recursively looks for the deepest call stack attached in its RootError chain,
+// since it's the closest one to where the error was produced.
+// It returns nil if no call stack was captured.
+func Stack(err error) StackTrace {
+	var customError CustomError
+	if !e.As(err, &customError) {
+		return nil
+	}
+
+	if trace := Stack(customError.rootErr); trace != nil {
+		return trace
+	}
+
+	if customError.stack == nil {
+		return nil
+	}
+
+	return customError.stack.frames()
+}
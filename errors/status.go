@@ -0,0 +1,142 @@
+package errors
+
+import "sync"
+
+// StatusMapping maps errors to transport status codes, overriding the default mapping of HTTP and gRPC error handlers.
+type StatusMapping struct {
+	// Kind is the KindType of the mapped errors. It can be left empty when Code is informed.
+	Kind KindType
+	// Code restricts the mapping to errors with the given CodeType. It can be left empty to map all errors of the given Kind.
+	Code CodeType
+	// HTTPStatus is the HTTP status code of the mapped errors. Zero keeps the default mapping.
+	HTTPStatus int
+	// GRPCStatus is the gRPC status code (google.golang.org/grpc/codes.Code) of the mapped errors. Zero keeps the default mapping.
+	GRPCStatus uint32
+}
+
+type statusKey struct {
+	kind KindType
+	code CodeType
+}
+
+var (
+	statusMappingsMu sync.RWMutex
+	statusMappings   = map[statusKey]StatusMapping{}
+)
+
+// RegisterStatusMapping registers a new StatusMapping, shared by HTTP and gRPC error handlers.
+// It fails if neither Kind nor Code are informed, or if the same Kind and Code pair was already mapped.
+func RegisterStatusMapping(m StatusMapping) error {
+	if m.Kind == "" && m.Code == "" {
+		return NewValidationError("status mapping kind or code must be informed")
+	}
+
+	statusMappingsMu.Lock()
+	defer statusMappingsMu.Unlock()
+
+	key := statusKey{kind: m.Kind, code: m.Code}
+	if _, ok := statusMappings[key]; ok {
+		return New("status mapping for kind '%s' and code '%s' is already registered", m.Kind, m.Code).WithKind(KindConflict).WithCode("DUPLICATED_STATUS_MAPPING")
+	}
+
+	statusMappings[key] = m
+	return nil
+}
+
+// MustRegisterStatusMapping registers a new StatusMapping, shared by HTTP and gRPC error handlers.
+// It panics if the mapping can't be registered.
+func MustRegisterStatusMapping(m StatusMapping) {
+	if err := RegisterStatusMapping(m); err != nil {
+		panic(err)
+	}
+}
+
+// HTTPStatus returns the HTTP status code registered for the given error, if any.
+// Mappings of the error Code take precedence over mappings of its Kind.
+func HTTPStatus(err error) (int, bool) {
+	for _, m := range lookupStatusMappings(err) {
+		if m.HTTPStatus != 0 {
+			return m.HTTPStatus, true
+		}
+	}
+	return 0, false
+}
+
+// GRPCStatus returns the gRPC status code registered for the given error, if any.
+// Mappings of the error Code take precedence over mappings of its Kind.
+func GRPCStatus(err error) (uint32, bool) {
+	for _, m := range lookupStatusMappings(err) {
+		if m.GRPCStatus != 0 {
+			return m.GRPCStatus, true
+		}
+	}
+	return 0, false
+}
+
+// HTTPStatusKind returns the Kind of the errors mapped into the given HTTP status code, if any.
+// It allows clients to rebuild errors from responses of servers that share the same mappings.
+// Mappings of the given Code take precedence over mappings of Kinds only, and mappings without Kind are ignored.
+func HTTPStatusKind(status int, code CodeType) (KindType, bool) {
+	statusMappingsMu.RLock()
+	defer statusMappingsMu.RUnlock()
+
+	var kind KindType
+	for key, m := range statusMappings {
+		if m.HTTPStatus != status || key.kind == "" {
+			continue
+		}
+
+		if code != "" && key.code == code {
+			return key.kind, true
+		}
+
+		// Kinds mapped into the same status are picked by precedence, so the result doesn't depend on the map order.
+		if key.code == "" && (kind == "" || prevails(key.kind, kind)) {
+			kind = key.kind
+		}
+	}
+
+	return kind, kind != ""
+}
+
+// prevails reports whether the kind a has a higher precedence than b. Kinds with the same precedence are sorted alphabetically.
+func prevails(a, b KindType) bool {
+	if kindRank(a) != kindRank(b) {
+		return kindRank(a) < kindRank(b)
+	}
+	return a < b
+}
+
+// resetStatusMappings removes all registered mappings, and returns a function that restores them.
+// It allows tests to register mappings without leaking them.
+func resetStatusMappings() (restore func()) {
+	statusMappingsMu.Lock()
+	defer statusMappingsMu.Unlock()
+
+	previous := statusMappings
+	statusMappings = map[statusKey]StatusMapping{}
+
+	return func() {
+		statusMappingsMu.Lock()
+		defer statusMappingsMu.Unlock()
+
+		statusMappings = previous
+	}
+}
+
+// lookupStatusMappings returns the mappings that match the given error, from the most to the least specific one.
+func lookupStatusMappings(err error) []StatusMapping {
+	kind, code := Kind(err), Code(err)
+
+	statusMappingsMu.RLock()
+	defer statusMappingsMu.RUnlock()
+
+	mappings := []StatusMapping{}
+	for _, key := range []statusKey{{kind, code}, {"", code}, {kind, ""}} {
+		if m, ok := statusMappings[key]; ok {
+			mappings = append(mappings, m)
+		}
+	}
+
+	return mappings
+}
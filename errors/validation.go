@@ -0,0 +1,57 @@
+package errors
+
+import e "errors"
+
+// FieldViolation describes why a given field of an input is invalid.
+type FieldViolation struct {
+	// Field is the path of the invalid field, like "items[0].name".
+	Field string `json:"field"`
+	// Rule is the name of the broken validation rule, like "required".
+	Rule string `json:"rule"`
+	// Message is a human readable description of the violation.
+	Message string `json:"message"`
+}
+
+// violationList holds the FieldViolations attached into a CustomError.
+type violationList []FieldViolation
+
+// WithViolations returns a copy of the CustomError with the given FieldViolations appended.
+func (ce CustomError) WithViolations(violations ...FieldViolation) CustomError {
+	if len(violations) == 0 {
+		return ce
+	}
+
+	list := violationList{}
+	if ce.violations != nil {
+		list = append(list, *ce.violations...)
+	}
+	list = append(list, violations...)
+
+	ce.violations = &list
+	return ce
+}
+
+// Violations tries to convert the given error into a CustomError.
+// If so, it returns the FieldViolations attached along its RootError chain, from the outermost to the innermost error.
+// For MultiErrors, the FieldViolations of all aggregated errors are returned.
+func Violations(err error) []FieldViolation {
+	if multiErr, ok := outermost(err).(*MultiError); ok {
+		violations := []FieldViolation{}
+		for _, err := range multiErr.errs {
+			violations = append(violations, Violations(err)...)
+		}
+		return violations
+	}
+
+	var customError CustomError
+	if !e.As(err, &customError) {
+		return []FieldViolation{}
+	}
+
+	violations := []FieldViolation{}
+	if customError.violations != nil {
+		violations = append(violations, *customError.violations...)
+	}
+
+	return append(violations, Violations(customError.rootErr)...)
+}
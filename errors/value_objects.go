@@ -2,11 +2,38 @@ package errors
 
 var (
 	// ErrResourceNotFound indicates that a desired resource was not found.
-	ErrResourceNotFound error = New("resource not found").WithKind(KindNotFound).WithCode("RESOURCE_NOT_FOUND")
+	ErrResourceNotFound error = MustRegister(CodeDefinition{
+		Code:        "RESOURCE_NOT_FOUND",
+		Kind:        KindNotFound,
+		Message:     "resource not found",
+		Description: "A desired resource was not found.",
+	})
 
 	// ErrNotImplemented indicates that a given feature is not implemented yet.
-	ErrNotImplemented error = New("feature not implemented yet").WithCode("FEATURE_NOT_IMPLEMENTED")
+	ErrNotImplemented error = MustRegister(CodeDefinition{
+		Code:        "FEATURE_NOT_IMPLEMENTED",
+		Kind:        KindNotImplemented,
+		Message:     "feature not implemented yet",
+		Description: "The requested feature is not implemented yet.",
+	})
 
 	// ErrMock is a fake mocked that should be used in test scenarios.
 	ErrMock error = New("mocked error").WithCode("MOCKED_ERROR")
 )
+
+// Codes produced by this package constructors.
+var (
+	_ = MustRegister(CodeDefinition{
+		Code:        "MISSING_REQUIRED_DEPENDENCY",
+		Kind:        KindInvalidInput,
+		Message:     "Missing required dependency",
+		Description: "A required dependency was not informed to a constructor.",
+	})
+
+	_ = MustRegister(CodeDefinition{
+		Code:        "VALIDATION_ERROR",
+		Kind:        KindInvalidInput,
+		Message:     "validation error",
+		Description: "The input is invalid. The invalid fields are detailed by its violations.",
+	})
+)
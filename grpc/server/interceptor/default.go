@@ -2,19 +2,20 @@ package interceptor
 
 import (
 	"github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 
 	"github.com/trivelaapp/go-kit/grpc/server/interceptor/error_handler"
 	"github.com/trivelaapp/go-kit/grpc/server/interceptor/logging"
 	"github.com/trivelaapp/go-kit/grpc/server/interceptor/meter"
+	"github.com/trivelaapp/go-kit/grpc/server/interceptor/recovery"
 )
 
 // DefaultInput encapsulates inputs to a Default call.
 type DefaultInput struct {
 	ApplicationName string
 	Logger          logging.Logger
+	ErrorHandler    error_handler.InterceptorParams
 }
 
 // Default returns a list of interceptors usually used by most applications. It includes:
@@ -26,18 +27,18 @@ type DefaultInput struct {
 func Default(in DefaultInput) []grpc.ServerOption {
 	return []grpc.ServerOption{
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			otelgrpc.UnaryServerInterceptor(),
 			meter.UnaryServerInterceptor(in.ApplicationName),
+			recovery.UnaryServerInterceptor(in.Logger),
+			otelgrpc.UnaryServerInterceptor(),
 			logging.UnaryServerInterceptor(in.Logger),
-			error_handler.UnaryServerInterceptor(),
-			grpc_recovery.UnaryServerInterceptor(),
+			error_handler.UnaryServerInterceptor(in.ErrorHandler),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			otelgrpc.StreamServerInterceptor(),
 			meter.StreamServerInterceptor(in.ApplicationName),
+			recovery.StreamServerInterceptor(in.Logger),
+			otelgrpc.StreamServerInterceptor(),
 			logging.StreamServerInterceptor(in.Logger),
-			error_handler.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(),
+			error_handler.StreamServerInterceptor(in.ErrorHandler),
 		)),
 	}
 }
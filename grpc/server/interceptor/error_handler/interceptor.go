@@ -6,11 +6,19 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 
-	"github.com/trivelaapp/go-kit/errors"
+	kit_status "github.com/trivelaapp/go-kit/grpc/status"
 )
 
+// InterceptorParams encapsulates the parameters of error handling interceptors.
+type InterceptorParams struct {
+	// ExposedFields is the allow-list of error fields (see errors.WithField) that can be included in responses.
+	ExposedFields []string
+}
+
 // UnaryServerInterceptor returns a new unary interceptor suitable for request error handling.
-func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(params ...InterceptorParams) grpc.UnaryServerInterceptor {
+	p := buildParams(params)
+
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -20,7 +28,7 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 
 		resp, err = handler(ctx, req)
 		if err != nil {
-			err = status.Error(kindToGRPCStatusCode(errors.Kind(err)), err.Error())
+			err = toStatusError(p, err)
 		}
 
 		return
@@ -28,7 +36,9 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 }
 
 // StreamServerInterceptor returns a new stream interceptor suitable for request error handling.
-func StreamServerInterceptor() grpc.StreamServerInterceptor {
+func StreamServerInterceptor(params ...InterceptorParams) grpc.StreamServerInterceptor {
+	p := buildParams(params)
+
 	return func(
 		srv interface{},
 		ss grpc.ServerStream,
@@ -38,9 +48,45 @@ func StreamServerInterceptor() grpc.StreamServerInterceptor {
 
 		err = handler(srv, ss)
 		if err != nil {
-			err = status.Error(kindToGRPCStatusCode(errors.Kind(err)), err.Error())
+			err = toStatusError(p, err)
 		}
 
 		return
 	}
 }
+
+func buildParams(params []InterceptorParams) InterceptorParams {
+	if len(params) > 0 {
+		return params[0]
+	}
+	return InterceptorParams{}
+}
+
+// statusError is an error that carries the gRPC status sent to clients.
+// It keeps the original error, so outer interceptors (like logging) still have access to all of its details.
+type statusError struct {
+	st  *status.Status
+	err error
+}
+
+// Error returns the original error message.
+func (se statusError) Error() string {
+	return se.err.Error()
+}
+
+// GRPCStatus returns the status sent to clients.
+func (se statusError) GRPCStatus() *status.Status {
+	return se.st
+}
+
+// Unwrap returns the original error.
+func (se statusError) Unwrap() error {
+	return se.err
+}
+
+func toStatusError(params InterceptorParams, err error) error {
+	return statusError{
+		st:  kit_status.Encode(err, params.ExposedFields...),
+		err: err,
+	}
+}
@@ -0,0 +1,101 @@
+package error_handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/trivelaapp/go-kit/errors"
+	kit_status "github.com/trivelaapp/go-kit/grpc/status"
+)
+
+var errOrderNotFound = errors.New("order not found").
+	WithKind(errors.KindNotFound).
+	WithCode("ORDER_NOT_FOUND").
+	WithField("order_id", 42).
+	WithField("customer_email", "john@trivela.com.br")
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/GetOrder"}
+
+	t.Run("should keep successful responses", func(t *testing.T) {
+		interceptor := UnaryServerInterceptor()
+
+		resp, err := interceptor(ctx, "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "response", nil
+		})
+		if resp != "response" || err != nil {
+			t.Errorf("Unexpected response '%v' and error '%v'.", resp, err)
+		}
+	})
+
+	t.Run("should convert errors into gRPC statuses exposing only allowed fields", func(t *testing.T) {
+		interceptor := UnaryServerInterceptor(InterceptorParams{ExposedFields: []string{"order_id"}})
+
+		_, err := interceptor(ctx, "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errOrderNotFound
+		})
+
+		assertStatusError(t, err)
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/orders.Orders/ListOrders"}
+
+	t.Run("should keep successful streams", func(t *testing.T) {
+		interceptor := StreamServerInterceptor()
+
+		err := interceptor(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should convert errors into gRPC statuses exposing only allowed fields", func(t *testing.T) {
+		interceptor := StreamServerInterceptor(InterceptorParams{ExposedFields: []string{"order_id"}})
+
+		err := interceptor(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return errOrderNotFound
+		})
+
+		assertStatusError(t, err)
+	})
+}
+
+func assertStatusError(t *testing.T, err error) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected an error carrying a gRPC status, got '%v'.", err)
+	}
+
+	if st.Code() != codes.NotFound || st.Message() != "order not found" {
+		t.Errorf("Mismatch status. Expected '%s: order not found', got '%s: %s'.", codes.NotFound, st.Code(), st.Message())
+	}
+
+	decoded := kit_status.Decode(st)
+	if errors.Kind(decoded) != errors.KindNotFound || errors.Code(decoded) != "ORDER_NOT_FOUND" {
+		t.Errorf("Mismatch decoded kind and code, got '%s' and '%s'.", errors.Kind(decoded), errors.Code(decoded))
+	}
+
+	fields := errors.Fields(decoded)
+	if fields["order_id"] != "42" {
+		t.Errorf("Expected exposed field order_id, got '%v'.", fields)
+	}
+	if _, ok := fields["customer_email"]; ok {
+		t.Errorf("Unexpected not exposed field customer_email, got '%v'.", fields)
+	}
+
+	// The original error is kept, so outer interceptors can log all of its details.
+	if errors.Code(err) != "ORDER_NOT_FOUND" || errors.Fields(err)["customer_email"] != "john@trivela.com.br" {
+		t.Errorf("Expected the original error to be kept, got '%v'.", err)
+	}
+}
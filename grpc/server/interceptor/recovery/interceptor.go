@@ -0,0 +1,62 @@
+package recovery
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/trivelaapp/go-kit/errors"
+	"github.com/trivelaapp/go-kit/grpc/server/interceptor/logging"
+	kit_status "github.com/trivelaapp/go-kit/grpc/status"
+)
+
+// UnaryServerInterceptor returns a new unary interceptor suitable for recovering from panics of the interceptors and handlers that come after it,
+// so it should be registered before them (only the meter interceptor should come first, so recovered panics are still measured).
+// The panic is turned into a CustomError of KindInternal (see errors.FromPanic), logged as critical with the panic call stack,
+// and returned as a status error, as the error_handler interceptor would do it.
+// Since the panic interrupts the logging interceptor, the request is only logged by the recovery interceptor.
+func UnaryServerInterceptor(logger logging.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = handlePanic(ctx, logger, errors.FromPanic(r))
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a new stream interceptor suitable for recovering from panics of the interceptors and handlers that come after it,
+// so it should be registered before them (only the meter interceptor should come first, so recovered panics are still measured).
+// The panic is turned into a CustomError of KindInternal (see errors.FromPanic), logged as critical with the panic call stack,
+// and returned as a status error, as the error_handler interceptor would do it.
+// Since the panic interrupts the logging interceptor, the request is only logged by the recovery interceptor.
+func StreamServerInterceptor(logger logging.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = handlePanic(ss.Context(), logger, errors.FromPanic(r))
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// handlePanic logs the recovered panic and returns the status error sent to clients.
+func handlePanic(ctx context.Context, logger logging.Logger, err error) error {
+	logger.Critical(ctx, err)
+
+	return kit_status.Encode(err).Err()
+}
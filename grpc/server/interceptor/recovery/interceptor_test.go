@@ -0,0 +1,150 @@
+package recovery
+
+import (
+	"context"
+	e "errors"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/trivelaapp/go-kit/errors"
+	"github.com/trivelaapp/go-kit/grpc/server/interceptor/error_handler"
+	"github.com/trivelaapp/go-kit/grpc/server/interceptor/logging"
+	kit_status "github.com/trivelaapp/go-kit/grpc/status"
+)
+
+// loggerMock records the errors logged by each level.
+type loggerMock struct {
+	errors    []error
+	criticals []error
+}
+
+func (l *loggerMock) Debug(ctx context.Context, msg string, args ...interface{})   {}
+func (l *loggerMock) Info(ctx context.Context, msg string, args ...interface{})    {}
+func (l *loggerMock) Warning(ctx context.Context, msg string, args ...interface{}) {}
+func (l *loggerMock) Error(ctx context.Context, err error)                         { l.errors = append(l.errors, err) }
+func (l *loggerMock) Critical(ctx context.Context, err error)                      { l.criticals = append(l.criticals, err) }
+func (l *loggerMock) Fatal(ctx context.Context, err error)                         { l.criticals = append(l.criticals, err) }
+
+// serverStreamMock is a grpc.ServerStream that only provides its context.
+type serverStreamMock struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStreamMock) Context() context.Context {
+	return s.ctx
+}
+
+// assertRecoveredPanic checks that the panic was logged once, as critical and with its call stack,
+// and that clients receive it as an internal status error.
+func assertRecoveredPanic(t *testing.T, logger *loggerMock, err error) {
+	t.Helper()
+
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected status code to be '%s': received '%s'", codes.Internal, status.Code(err))
+	}
+
+	if !e.Is(kit_status.DecodeError(err), errors.ErrPanicRecovered) {
+		t.Errorf("Expected status error to carry the recovered panic, got %v", err)
+	}
+
+	if len(logger.criticals) != 1 || len(logger.errors) != 0 {
+		t.Fatalf("Expected a single critical log: received %d critical and %d error logs", len(logger.criticals), len(logger.errors))
+	}
+
+	if !e.Is(logger.criticals[0], errors.ErrPanicRecovered) || errors.Stack(logger.criticals[0]) == nil {
+		t.Errorf("Expected logged error to be a recovered panic with its call stack, got %v", logger.criticals[0])
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("mocked panic")
+	}
+
+	t.Run("should turn handler panics into internal status errors", func(t *testing.T) {
+		logger := &loggerMock{}
+
+		_, err := UnaryServerInterceptor(logger)(ctx, nil, info, panicking)
+		assertRecoveredPanic(t, logger, err)
+	})
+
+	t.Run("should return handler responses when there's no panic", func(t *testing.T) {
+		logger := &loggerMock{}
+
+		resp, err := UnaryServerInterceptor(logger)(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "response", nil
+		})
+
+		if resp != "response" || err != nil {
+			t.Errorf("Expected handler response, got '%v' and '%v'", resp, err)
+		}
+
+		if len(logger.criticals) != 0 {
+			t.Errorf("Expected no critical logs: received %d", len(logger.criticals))
+		}
+	})
+
+	t.Run("should recover from interceptor panics and log them once when registered first", func(t *testing.T) {
+		logger := &loggerMock{}
+		chain := grpc_middleware.ChainUnaryServer(
+			UnaryServerInterceptor(logger),
+			logging.UnaryServerInterceptor(logger),
+			error_handler.UnaryServerInterceptor(),
+		)
+
+		_, err := chain(ctx, nil, info, panicking)
+		assertRecoveredPanic(t, logger, err)
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	ss := serverStreamMock{ctx: context.Background()}
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+
+	panicking := func(srv interface{}, stream grpc.ServerStream) error {
+		panic("mocked panic")
+	}
+
+	t.Run("should turn handler panics into internal status errors", func(t *testing.T) {
+		logger := &loggerMock{}
+
+		err := StreamServerInterceptor(logger)(nil, ss, info, panicking)
+		assertRecoveredPanic(t, logger, err)
+	})
+
+	t.Run("should return handler errors when there's no panic", func(t *testing.T) {
+		logger := &loggerMock{}
+
+		err := StreamServerInterceptor(logger)(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return errors.ErrMock
+		})
+
+		if err != errors.ErrMock {
+			t.Errorf("Expected handler error, got '%v'", err)
+		}
+
+		if len(logger.criticals) != 0 {
+			t.Errorf("Expected no critical logs: received %d", len(logger.criticals))
+		}
+	})
+
+	t.Run("should recover from interceptor panics and log them once when registered first", func(t *testing.T) {
+		logger := &loggerMock{}
+		chain := grpc_middleware.ChainStreamServer(
+			StreamServerInterceptor(logger),
+			logging.StreamServerInterceptor(logger),
+			error_handler.StreamServerInterceptor(),
+		)
+
+		err := chain(nil, ss, info, panicking)
+		assertRecoveredPanic(t, logger, err)
+	})
+}
@@ -0,0 +1,125 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpc_status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// Encode converts the given error into a gRPC status, exposing only the error public message.
+// A google.rpc.ErrorInfo detail is attached carrying the error code and kind, so they can be recovered by Decode.
+// The error fields in exposedFields allow-list are attached to its metadata as well, with their keys prefixed by ErrorInfoFieldPrefix.
+// Field violations and retry hints are attached as google.rpc.BadRequest and google.rpc.RetryInfo details, respectively.
+func Encode(err error, exposedFields ...string) *grpc_status.Status {
+	st := grpc_status.New(grpcStatusCode(err), errors.PublicMessage(err))
+
+	metadata := buildMetadata(err, exposedFields)
+	metadata[ErrorInfoKindKey] = string(errors.Kind(err))
+	st = withDetails(st, &errdetails.ErrorInfo{
+		Reason:   string(errors.Code(err)),
+		Domain:   ErrorInfoDomain,
+		Metadata: metadata,
+	})
+
+	if violations := errors.Violations(err); len(violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Message,
+			})
+		}
+		st = withDetails(st, badRequest)
+	}
+
+	if after, ok := errors.RetryAfter(err); ok {
+		st = withDetails(st, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(after),
+		})
+	}
+
+	return st
+}
+
+// Decode converts the given gRPC status into a CustomError.
+// Kind, code and exposed fields are recovered from the google.rpc.ErrorInfo detail attached by Encode.
+// If there's none, the kind is inferred from the status code. Unknown details are ignored.
+func Decode(st *grpc_status.Status) errors.CustomError {
+	err := errors.New("%s", st.Message()).WithKind(grpcStatusCodeToKind(st.Code()))
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() != ErrorInfoDomain {
+				continue
+			}
+
+			err = err.WithCode(errors.CodeType(d.GetReason()))
+			for key, value := range d.GetMetadata() {
+				if key == ErrorInfoKindKey {
+					err = err.WithKind(errors.KindType(value))
+					continue
+				}
+
+				if field := strings.TrimPrefix(key, ErrorInfoFieldPrefix); field != key {
+					err = err.WithField(field, value)
+				}
+			}
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				err = err.WithViolations(errors.FieldViolation{
+					Field:   violation.GetField(),
+					Message: violation.GetDescription(),
+				})
+			}
+		case *errdetails.RetryInfo:
+			err = err.WithRetryAfter(d.GetRetryDelay().AsDuration())
+		}
+	}
+
+	return err
+}
+
+// DecodeError converts an error received from a gRPC call into a CustomError, preserving its kind, code and message.
+// Errors that don't carry a gRPC status are returned unchanged.
+func DecodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := grpc_status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return Decode(st)
+}
+
+// withDetails attaches the given detail into the status.
+// If it can't be attached, the status is returned unchanged, since details are just complementary information.
+func withDetails(st *grpc_status.Status, detail proto.Message) *grpc_status.Status {
+	detailed, err := st.WithDetails(detail)
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+func buildMetadata(err error, exposedFields []string) map[string]string {
+	fields := errors.Fields(err)
+
+	metadata := map[string]string{}
+	for _, key := range exposedFields {
+		if value, ok := fields[key]; ok {
+			metadata[ErrorInfoFieldPrefix+key] = fmt.Sprint(value)
+		}
+	}
+
+	return metadata
+}
@@ -0,0 +1,199 @@
+package status
+
+import (
+	e "errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+type decodedError struct {
+	Message    string
+	Kind       errors.KindType
+	Code       errors.CodeType
+	Fields     map[string]any
+	Violations []errors.FieldViolation
+	RetryAfter time.Duration
+}
+
+func describeError(err error) decodedError {
+	after, _ := errors.RetryAfter(err)
+	return decodedError{
+		Message:    err.Error(),
+		Kind:       errors.Kind(err),
+		Code:       errors.Code(err),
+		Fields:     errors.Fields(err),
+		Violations: errors.Violations(err),
+		RetryAfter: after,
+	}
+}
+
+func mustWithDetails(t *testing.T, st *grpc_status.Status, details ...proto.Message) *grpc_status.Status {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		t.Fatalf("Could not attach details: %v", err)
+	}
+	return detailed
+}
+
+// orderNotFoundError is an error with all the information carried by status details.
+var orderNotFoundError = errors.New("order 42 not found in database").
+	WithKind(errors.KindNotFound).
+	WithCode("ORDER_NOT_FOUND").
+	WithPublicMessage("order not found").
+	WithFields(map[string]any{"order_id": 42, "kind": "physical", "user_id": "secret"}).
+	WithViolations(errors.FieldViolation{Field: "order_id", Rule: "exists", Message: "order must exist"}).
+	WithRetryAfter(2 * time.Second)
+
+func TestEncode(t *testing.T) {
+	st := Encode(orderNotFoundError, "order_id", "kind")
+
+	if st.Code() != codes.NotFound {
+		t.Errorf("Expected status code to be '%s': received '%s'", codes.NotFound, st.Code())
+	}
+
+	if st.Message() != "order not found" {
+		t.Errorf("Expected status message to be the public message: received '%s'", st.Message())
+	}
+
+	details := st.Details()
+	if len(details) != 3 {
+		t.Fatalf("Expected 3 details: received %d", len(details))
+	}
+
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected first detail to be an ErrorInfo: received %T", details[0])
+	}
+
+	if errorInfo.GetReason() != "ORDER_NOT_FOUND" || errorInfo.GetDomain() != ErrorInfoDomain {
+		t.Errorf("Wrong ErrorInfo reason '%s' or domain '%s'", errorInfo.GetReason(), errorInfo.GetDomain())
+	}
+
+	expectedMetadata := map[string]string{"kind": "NOT_FOUND", "field_order_id": "42", "field_kind": "physical"}
+	if diff := cmp.Diff(expectedMetadata, errorInfo.GetMetadata()); diff != "" {
+		t.Errorf("ErrorInfo metadata mismatch (-want, +got):\n%s", diff)
+	}
+
+	badRequest, ok := details[1].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("Expected second detail to be a BadRequest: received %T", details[1])
+	}
+
+	violations := badRequest.GetFieldViolations()
+	if len(violations) != 1 || violations[0].GetField() != "order_id" || violations[0].GetDescription() != "order must exist" {
+		t.Errorf("Wrong BadRequest field violations: %v", violations)
+	}
+
+	retryInfo, ok := details[2].(*errdetails.RetryInfo)
+	if !ok {
+		t.Fatalf("Expected third detail to be a RetryInfo: received %T", details[2])
+	}
+
+	if delay := retryInfo.GetRetryDelay().AsDuration(); delay != 2*time.Second {
+		t.Errorf("Wrong RetryInfo delay: %s", delay)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tt := []struct {
+		desc     string
+		st       *grpc_status.Status
+		expected decodedError
+	}{
+		{
+			desc: "should round trip kind, code, public message, exposed fields, violations and retry hints",
+			st:   Encode(orderNotFoundError, "order_id", "kind"),
+			expected: decodedError{
+				Message:    "order not found",
+				Kind:       errors.KindNotFound,
+				Code:       "ORDER_NOT_FOUND",
+				Fields:     map[string]any{"order_id": "42", "kind": "physical"},
+				Violations: []errors.FieldViolation{{Field: "order_id", Message: "order must exist"}},
+				RetryAfter: 2 * time.Second,
+			},
+		},
+		{
+			desc: "should round trip custom kinds",
+			st:   Encode(errors.New("custom").WithKind("CUSTOM_KIND").WithCode("CUSTOM_CODE")),
+			expected: decodedError{
+				Message: "custom",
+				Kind:    "CUSTOM_KIND",
+				Code:    "CUSTOM_CODE",
+			},
+		},
+		{
+			desc: "should infer the kind from the status code when there are no details",
+			st:   grpc_status.New(codes.PermissionDenied, "forbidden"),
+			expected: decodedError{
+				Message: "forbidden",
+				Kind:    errors.KindUnauthorized,
+				Code:    errors.CodeUnknown,
+			},
+		},
+		{
+			desc: "should ignore unknown details and ErrorInfos of other domains",
+			st: mustWithDetails(t, grpc_status.New(codes.Unavailable, "unavailable"),
+				&errdetails.DebugInfo{Detail: "stack"},
+				&errdetails.ErrorInfo{Reason: "FOREIGN", Domain: "example.com", Metadata: map[string]string{"kind": "FOREIGN", "field_foo": "bar"}},
+			),
+			expected: decodedError{
+				Message: "unavailable",
+				Kind:    errors.KindUnavailable,
+				Code:    errors.CodeUnknown,
+			},
+		},
+		{
+			desc: "should ignore ErrorInfo metadata keys that are neither the kind nor exposed fields",
+			st: mustWithDetails(t, grpc_status.New(codes.NotFound, "not found"),
+				&errdetails.ErrorInfo{Reason: "NOT_FOUND", Domain: ErrorInfoDomain, Metadata: map[string]string{"other": "value", "field_id": "1"}},
+			),
+			expected: decodedError{
+				Message: "not found",
+				Kind:    errors.KindNotFound,
+				Code:    "NOT_FOUND",
+				Fields:  map[string]any{"id": "1"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, describeError(Decode(tc.st)), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Run("should return nil for nil errors", func(t *testing.T) {
+		if err := DecodeError(nil); err != nil {
+			t.Errorf("Expected nil, got '%v'", err)
+		}
+	})
+
+	t.Run("should return errors without status unchanged", func(t *testing.T) {
+		err := e.New("some error")
+		if decoded := DecodeError(err); decoded != err {
+			t.Errorf("Expected error to be unchanged, got '%v'", decoded)
+		}
+	})
+
+	t.Run("should decode errors with status", func(t *testing.T) {
+		err := Encode(errors.New("not found").WithKind(errors.KindNotFound).WithCode("ORDER_NOT_FOUND")).Err()
+
+		expected := decodedError{Message: "not found", Kind: errors.KindNotFound, Code: "ORDER_NOT_FOUND"}
+		if diff := cmp.Diff(expected, describeError(DecodeError(err)), cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+}
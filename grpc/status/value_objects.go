@@ -0,0 +1,90 @@
+package status
+
+import (
+	"google.golang.org/grpc/codes"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// ErrorInfoDomain is the domain of the google.rpc.ErrorInfo details attached by go-kit.
+const ErrorInfoDomain = "github.com/trivelaapp/go-kit"
+
+// ErrorInfoKindKey is the google.rpc.ErrorInfo metadata key that carries the error kind.
+const ErrorInfoKindKey = "kind"
+
+// ErrorInfoFieldPrefix prefixes the google.rpc.ErrorInfo metadata keys that carry exposed error fields,
+// so they can't be mistaken by the error kind.
+const ErrorInfoFieldPrefix = "field_"
+
+// grpcStatusCode returns the gRPC status code registered for the given error (see errors.RegisterStatusMapping).
+// If there's none, the default status code of its kind is returned.
+func grpcStatusCode(err error) codes.Code {
+	if code, ok := errors.GRPCStatus(err); ok {
+		return codes.Code(code)
+	}
+	return kindToGRPCStatusCode(errors.Kind(err))
+}
+
+func kindToGRPCStatusCode(kind errors.KindType) codes.Code {
+	switch kind {
+	case errors.KindInvalidInput:
+		return codes.InvalidArgument
+	case errors.KindUnauthenticated:
+		return codes.Unauthenticated
+	case errors.KindUnauthorized:
+		return codes.PermissionDenied
+	case errors.KindNotFound:
+		return codes.NotFound
+	case errors.KindConflict:
+		return codes.FailedPrecondition
+	case errors.KindUnexpected:
+		return codes.Unknown
+	case errors.KindInternal:
+		return codes.Internal
+	case errors.KindResourceExhausted:
+		return codes.ResourceExhausted
+	case errors.KindTimeout:
+		return codes.DeadlineExceeded
+	case errors.KindUnavailable:
+		return codes.Unavailable
+	case errors.KindCanceled:
+		return codes.Canceled
+	case errors.KindFailedPrecondition:
+		return codes.FailedPrecondition
+	case errors.KindNotImplemented:
+		return codes.Unimplemented
+	default:
+		return codes.Unknown
+	}
+}
+
+func grpcStatusCodeToKind(code codes.Code) errors.KindType {
+	switch code {
+	case codes.InvalidArgument:
+		return errors.KindInvalidInput
+	case codes.Unauthenticated:
+		return errors.KindUnauthenticated
+	case codes.PermissionDenied:
+		return errors.KindUnauthorized
+	case codes.NotFound:
+		return errors.KindNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return errors.KindConflict
+	case codes.Internal, codes.DataLoss:
+		return errors.KindInternal
+	case codes.ResourceExhausted:
+		return errors.KindResourceExhausted
+	case codes.DeadlineExceeded:
+		return errors.KindTimeout
+	case codes.Unavailable:
+		return errors.KindUnavailable
+	case codes.Canceled:
+		return errors.KindCanceled
+	case codes.FailedPrecondition, codes.OutOfRange:
+		return errors.KindFailedPrecondition
+	case codes.Unimplemented:
+		return errors.KindNotImplemented
+	default:
+		return errors.KindUnexpected
+	}
+}
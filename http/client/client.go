@@ -106,6 +106,7 @@ func (c Client) processRequest(ctx context.Context, method string, request HTTPR
 
 	return HTTPResult{
 		Response:   body,
+		Headers:    res.Header,
 		StatusCode: res.StatusCode,
 	}, nil
 }
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// statusClientClosedRequest is the non-standard status code (introduced by nginx) used when the client cancels the request.
+const statusClientClosedRequest = 499
+
+// mimeProblemJSON is the media type of RFC 7807 problem details documents.
+const mimeProblemJSON = "application/problem+json"
+
+// errorResponse is the error payload written by servers that use go-kit middleware.ErrorHandler.
+type errorResponse struct {
+	TraceID string                `json:"trace_id"`
+	Err     *errors.CustomError   `json:"error"`
+	Errs    []*errors.CustomError `json:"errors"`
+}
+
+// problemDetailsResponse is the RFC 7807 problem details document written by servers that use go-kit middleware.ErrorHandler
+// with problem details enabled. Only the members needed to rebuild the error are decoded.
+type problemDetailsResponse struct {
+	Title      string                  `json:"title"`
+	Detail     string                  `json:"detail"`
+	TraceID    string                  `json:"trace_id"`
+	Code       errors.CodeType         `json:"code"`
+	Details    map[string]any          `json:"details"`
+	Violations []errors.FieldViolation `json:"violations"`
+	Errs       []*errors.CustomError   `json:"errors"`
+}
+
+// DecodeError rebuilds the error sent by a server that uses go-kit middleware.ErrorHandler, preserving its code and message.
+// Both the default payload and RFC 7807 problem details (application/problem+json) are supported, based on the response Content-Type.
+// Since the payload doesn't carry the error kind, it's inferred from each error code, when it's registered (see errors.Register).
+// Otherwise, it's inferred from the response status code, considering the registered status mappings (see errors.RegisterStatusMapping).
+// Error lists are rebuilt as an errors.MultiError, and the upstream trace id is attached as the "trace_id" field.
+// It returns nil when the result is not an error response.
+func DecodeError(result HTTPResult) error {
+	if result.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(result.Headers.Get("Content-Type")); mediaType == mimeProblemJSON {
+		err = decodeProblemDetails(result)
+	} else {
+		err = decodeErrorResponse(result)
+	}
+
+	if err == nil {
+		return errors.New("request failed with status code %d", result.StatusCode).WithKind(httpStatusCodeToKind(result.StatusCode, ""))
+	}
+
+	return err
+}
+
+func decodeErrorResponse(result HTTPResult) error {
+	var res errorResponse
+	if err := json.Unmarshal(result.Response, &res); err != nil {
+		return nil
+	}
+
+	if res.Err != nil {
+		return rebuildError(*res.Err, result.StatusCode, res.TraceID)
+	}
+
+	return rebuildErrorList(res.Errs, result.StatusCode, res.TraceID)
+}
+
+func decodeProblemDetails(result HTTPResult) error {
+	var res problemDetailsResponse
+	if err := json.Unmarshal(result.Response, &res); err != nil {
+		return nil
+	}
+
+	if len(res.Errs) > 0 {
+		return rebuildErrorList(res.Errs, result.StatusCode, res.TraceID)
+	}
+
+	msg := res.Detail
+	if msg == "" {
+		msg = res.Title
+	}
+
+	if msg == "" && res.Code == "" {
+		return nil
+	}
+
+	err := errors.New("%s", msg).WithViolations(res.Violations...)
+	if res.Code != "" {
+		err = err.WithCode(res.Code)
+	}
+
+	if len(res.Details) > 0 {
+		err = err.WithFields(res.Details)
+	}
+
+	return rebuildError(err, result.StatusCode, res.TraceID)
+}
+
+// rebuildErrorList rebuilds each error of the list, discarding null entries.
+// It returns nil when there are no errors to rebuild.
+func rebuildErrorList(list []*errors.CustomError, statusCode int, traceID string) error {
+	errs := []error{}
+	for _, err := range list {
+		if err == nil {
+			continue
+		}
+		errs = append(errs, rebuildError(*err, statusCode, traceID))
+	}
+
+	return errors.Join(errs...)
+}
+
+// rebuildError infers the kind of the error from its code, when it's registered (see errors.Register).
+// Otherwise, it's inferred from the response status code.
+func rebuildError(err errors.CustomError, statusCode int, traceID string) errors.CustomError {
+	if errors.Kind(err) == errors.KindUnexpected {
+		if def, ok := errors.Lookup(errors.Code(err)); ok && def.Kind != "" {
+			err = err.WithKind(def.Kind)
+		} else {
+			err = err.WithKind(httpStatusCodeToKind(statusCode, errors.Code(err)))
+		}
+	}
+
+	if traceID != "" {
+		err = err.WithField("trace_id", traceID)
+	}
+
+	return err
+}
+
+// httpStatusCodeToKind returns the kind registered for the given status code and error code (see errors.HTTPStatusKind).
+// If there's none, the default kind of the status code is returned.
+func httpStatusCodeToKind(statusCode int, code errors.CodeType) errors.KindType {
+	if kind, ok := errors.HTTPStatusKind(statusCode, code); ok {
+		return kind
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return errors.KindInvalidInput
+	case http.StatusUnauthorized:
+		return errors.KindUnauthenticated
+	case http.StatusForbidden:
+		return errors.KindUnauthorized
+	case http.StatusNotFound:
+		return errors.KindNotFound
+	case http.StatusConflict:
+		return errors.KindConflict
+	case http.StatusTooManyRequests:
+		return errors.KindResourceExhausted
+	case http.StatusGatewayTimeout:
+		return errors.KindTimeout
+	case http.StatusServiceUnavailable:
+		return errors.KindUnavailable
+	case statusClientClosedRequest:
+		return errors.KindCanceled
+	case http.StatusPreconditionFailed:
+		return errors.KindFailedPrecondition
+	case http.StatusNotImplemented:
+		return errors.KindNotImplemented
+	default:
+		return errors.KindUnexpected
+	}
+}
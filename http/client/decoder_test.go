@@ -0,0 +1,164 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func init() {
+	errors.MustRegisterStatusMapping(errors.StatusMapping{Kind: errors.KindConflict, Code: "DECODER_MAPPED_CODE", HTTPStatus: http.StatusUnprocessableEntity})
+	errors.MustRegister(errors.CodeDefinition{Code: "DECODER_INVALID_NAME", Kind: errors.KindInvalidInput, Message: "invalid name"})
+}
+
+type decodedError struct {
+	Message    string
+	Kind       errors.KindType
+	Code       errors.CodeType
+	Fields     map[string]any
+	Violations []errors.FieldViolation
+}
+
+func describeErrors(err error) []decodedError {
+	if err == nil {
+		return nil
+	}
+
+	errs := errors.Errors(err)
+	if errs == nil {
+		errs = []error{err}
+	}
+
+	decoded := []decodedError{}
+	for _, err := range errs {
+		decoded = append(decoded, decodedError{
+			Message:    err.Error(),
+			Kind:       errors.Kind(err),
+			Code:       errors.Code(err),
+			Fields:     errors.Fields(err),
+			Violations: errors.Violations(err),
+		})
+	}
+
+	return decoded
+}
+
+func TestDecodeError(t *testing.T) {
+	jsonHeaders := http.Header{"Content-Type": []string{"application/json; charset=utf-8"}}
+	problemHeaders := http.Header{"Content-Type": []string{"application/problem+json"}}
+
+	tt := []struct {
+		desc     string
+		result   HTTPResult
+		expected []decodedError
+	}{
+		{
+			desc:   "should return nil for successful responses",
+			result: HTTPResult{StatusCode: http.StatusOK, Headers: jsonHeaders, Response: []byte(`{}`)},
+		},
+		{
+			desc:   "should rebuild a single error",
+			result: HTTPResult{StatusCode: http.StatusNotFound, Headers: jsonHeaders, Response: []byte(`{"trace_id":"abc","error":{"code":"ORDER_NOT_FOUND","message":"order not found"}}`)},
+			expected: []decodedError{
+				{Message: "order not found", Kind: errors.KindNotFound, Code: "ORDER_NOT_FOUND", Fields: map[string]any{"trace_id": "abc"}},
+			},
+		},
+		{
+			desc:   "should rebuild error lists discarding null entries",
+			result: HTTPResult{StatusCode: http.StatusBadRequest, Headers: jsonHeaders, Response: []byte(`{"errors":[null,{"code":"INVALID_NAME","message":"invalid name"},null]}`)},
+			expected: []decodedError{
+				{Message: "invalid name", Kind: errors.KindInvalidInput, Code: "INVALID_NAME"},
+			},
+		},
+		{
+			desc:   "should fall back to a generic error when the error list only has null entries",
+			result: HTTPResult{StatusCode: http.StatusBadRequest, Headers: jsonHeaders, Response: []byte(`{"errors":[null]}`)},
+			expected: []decodedError{
+				{Message: "request failed with status code 400", Kind: errors.KindInvalidInput, Code: errors.CodeUnknown},
+			},
+		},
+		{
+			desc:   "should fall back to a generic error on malformed bodies",
+			result: HTTPResult{StatusCode: http.StatusServiceUnavailable, Headers: jsonHeaders, Response: []byte(`<html>unavailable</html>`)},
+			expected: []decodedError{
+				{Message: "request failed with status code 503", Kind: errors.KindUnavailable, Code: errors.CodeUnknown},
+			},
+		},
+		{
+			desc:   "should fall back to a generic error on null bodies",
+			result: HTTPResult{StatusCode: http.StatusInternalServerError, Response: []byte(`null`)},
+			expected: []decodedError{
+				{Message: "request failed with status code 500", Kind: errors.KindUnexpected, Code: errors.CodeUnknown},
+			},
+		},
+		{
+			desc:   "should infer the kind from registered status mappings",
+			result: HTTPResult{StatusCode: http.StatusUnprocessableEntity, Headers: jsonHeaders, Response: []byte(`{"error":{"code":"DECODER_MAPPED_CODE","message":"conflict"}}`)},
+			expected: []decodedError{
+				{Message: "conflict", Kind: errors.KindConflict, Code: "DECODER_MAPPED_CODE"},
+			},
+		},
+		{
+			desc: "should rebuild a single error from problem details",
+			result: HTTPResult{StatusCode: http.StatusBadRequest, Headers: problemHeaders, Response: []byte(`{
+				"type":"urn:problem-type:VALIDATION_ERROR","title":"Bad Request","status":400,"detail":"invalid order",
+				"trace_id":"abc","code":"VALIDATION_ERROR","details":{"order_id":"42"},
+				"violations":[{"field":"items","rule":"required","message":"items are required"}]
+			}`)},
+			expected: []decodedError{
+				{
+					Message:    "invalid order",
+					Kind:       errors.KindInvalidInput,
+					Code:       "VALIDATION_ERROR",
+					Fields:     map[string]any{"order_id": "42", "trace_id": "abc"},
+					Violations: []errors.FieldViolation{{Field: "items", Rule: "required", Message: "items are required"}},
+				},
+			},
+		},
+		{
+			desc: "should rebuild error lists from problem details",
+			result: HTTPResult{StatusCode: http.StatusNotFound, Headers: problemHeaders, Response: []byte(`{
+				"type":"urn:problem-type:ORDER_NOT_FOUND","title":"Not Found","status":404,"detail":"order not found","code":"ORDER_NOT_FOUND",
+				"errors":[{"code":"ORDER_NOT_FOUND","message":"order not found"},null,{"code":"INVALID_NAME","message":"invalid name"}]
+			}`)},
+			expected: []decodedError{
+				{Message: "order not found", Kind: errors.KindNotFound, Code: "ORDER_NOT_FOUND"},
+				{Message: "invalid name", Kind: errors.KindNotFound, Code: "INVALID_NAME"},
+			},
+		},
+		{
+			desc:   "should infer the kind of each listed error from its registered code",
+			result: HTTPResult{StatusCode: http.StatusNotFound, Headers: jsonHeaders, Response: []byte(`{"errors":[{"code":"ORDER_NOT_FOUND","message":"order not found"},{"code":"DECODER_INVALID_NAME","message":"invalid name"}]}`)},
+			expected: []decodedError{
+				{Message: "order not found", Kind: errors.KindNotFound, Code: "ORDER_NOT_FOUND"},
+				{Message: "invalid name", Kind: errors.KindInvalidInput, Code: "DECODER_INVALID_NAME"},
+			},
+		},
+		{
+			desc:   "should fall back to the problem title when there's no detail",
+			result: HTTPResult{StatusCode: http.StatusConflict, Headers: problemHeaders, Response: []byte(`{"type":"about:blank","title":"Conflict","status":409}`)},
+			expected: []decodedError{
+				{Message: "Conflict", Kind: errors.KindConflict, Code: errors.CodeUnknown},
+			},
+		},
+		{
+			desc:   "should fall back to a generic error on malformed problem details",
+			result: HTTPResult{StatusCode: http.StatusConflict, Headers: problemHeaders, Response: []byte(`{"title":`)},
+			expected: []decodedError{
+				{Message: "request failed with status code 409", Kind: errors.KindConflict, Code: errors.CodeUnknown},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, describeErrors(DecodeError(tc.result)), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package client
 
+import "net/http"
+
 // HTTPHeaders is a map containing the relation key=value of the headers used on the http rest request.
 type HTTPHeaders map[string]string
 
@@ -17,5 +19,6 @@ type HTTPRequest struct {
 // HTTPResult are the params returned from the client HTTP request
 type HTTPResult struct {
 	StatusCode int
+	Headers    http.Header
 	Response   []byte
 }
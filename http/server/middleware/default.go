@@ -9,6 +9,7 @@ import (
 type DefaultInput struct {
 	ApplicationName string
 	Logger          LogProvider
+	ErrorHandler    ErrorHandlerParams
 }
 
 // Default returns a list of middlewares usually used by most applications. It includes:
@@ -20,9 +21,9 @@ type DefaultInput struct {
 func Default(in DefaultInput) []gin.HandlerFunc {
 	return []gin.HandlerFunc{
 		Meter(in.ApplicationName),
-		gin.Recovery(),
+		Recovery(in.Logger, in.ErrorHandler),
 		otelgin.Middleware(in.ApplicationName),
 		Logger(in.Logger),
-		ErrorHandler,
+		NewErrorHandler(in.ErrorHandler),
 	}
 }
@@ -2,40 +2,122 @@ package middleware
 
 import (
 	"context"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/trivelaapp/go-kit/errors"
 )
 
+// StatusClientClosedRequest is the non-standard status code (introduced by nginx) used when the client cancels the request.
+const StatusClientClosedRequest = 499
+
+// MIMEProblemJSON is the media type of RFC 7807 problem details documents.
+const MIMEProblemJSON = "application/problem+json"
+
+// DefaultProblemTypeBaseURI is the default prefix of problem details types, which are suffixed by the error code.
+const DefaultProblemTypeBaseURI = "urn:problem-type:"
+
+// ErrorHandlerParams encapsulates the parameters of an ErrorHandler.
+type ErrorHandlerParams struct {
+	// ExposedFields is the allow-list of error fields (see errors.WithField) that can be included in responses.
+	ExposedFields []string
+	// ProblemDetails enables RFC 7807 (application/problem+json) error responses.
+	// They are served when the request's Accept header is empty, or accepts application/problem+json at least as much as application/json.
+	ProblemDetails bool
+	// ProblemTypeBaseURI is the prefix of problem details types, which are suffixed by the error code.
+	// Defaults to DefaultProblemTypeBaseURI.
+	ProblemTypeBaseURI string
+}
+
 // ErrorHandler handles request errors, standardizing how error responses payloads should be served.
 func ErrorHandler(ctx *gin.Context) {
+	handleErrors(ctx, ErrorHandlerParams{})
+}
+
+// NewErrorHandler creates a new ErrorHandler middleware with the given parameters.
+func NewErrorHandler(params ErrorHandlerParams) func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		handleErrors(ctx, params)
+	}
+}
+
+func handleErrors(ctx *gin.Context, params ErrorHandlerParams) {
 	ctx.Next()
+	renderErrors(ctx, params)
+}
 
-	if len(ctx.Errors) == 0 {
+// renderErrors serves the errors attached to the request, if any.
+func renderErrors(ctx *gin.Context, params ErrorHandlerParams) {
+	errs := []error{}
+	for _, err := range ctx.Errors {
+		errs = append(errs, err.Err)
+	}
+
+	// Errors are flattened, so MultiErrors sent by handlers are also rendered as error lists.
+	multiErr, ok := errors.Join(errs...).(*errors.MultiError)
+	if !ok {
 		return
 	}
 
-	if len(ctx.Errors) == 1 {
-		res := newErrorResponse(ctx, ctx.Errors[0].Err)
+	setRetryAfterHeader(ctx, multiErr)
+
+	if params.ProblemDetails && acceptsProblemDetails(ctx) {
+		res := newProblemDetailsResponse(ctx, params, multiErr)
+		ctx.Header("Content-Type", MIMEProblemJSON)
 		ctx.JSON(res.StatusCode(), res)
 		return
 	}
 
-	errs := []error{}
-	for _, err := range ctx.Errors {
-		errs = append(errs, err.Err)
+	if len(multiErr.Errors()) == 1 {
+		res := newErrorResponse(ctx, params, multiErr.Errors()[0])
+		ctx.JSON(res.StatusCode(), res)
+		return
 	}
 
-	res := newErrorListResponse(ctx, errs...)
+	res := newErrorListResponse(ctx, params, multiErr)
 	ctx.JSON(res.StatusCode(), res)
 }
 
+// setRetryAfterHeader informs clients how many seconds they should wait before retrying, when the error has this hint.
+func setRetryAfterHeader(ctx *gin.Context, err error) {
+	if after, ok := errors.RetryAfter(err); ok {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+	}
+}
+
 type errorPayload struct {
-	Code    errors.CodeType `json:"code,omitempty"`
-	Message string          `json:"message"`
+	Code       errors.CodeType         `json:"code,omitempty"`
+	Message    string                  `json:"message"`
+	Details    map[string]any          `json:"details,omitempty"`
+	Violations []errors.FieldViolation `json:"violations,omitempty"`
+}
+
+func newErrorPayload(params ErrorHandlerParams, err error) errorPayload {
+	return errorPayload{
+		Code:       errors.Code(err),
+		Message:    errors.PublicMessage(err),
+		Details:    exposedFields(params, err),
+		Violations: errors.Violations(err),
+	}
+}
+
+func exposedFields(params ErrorHandlerParams, err error) map[string]any {
+	fields := errors.Fields(err)
+
+	exposed := map[string]any{}
+	for _, key := range params.ExposedFields {
+		if value, ok := fields[key]; ok {
+			exposed[key] = value
+		}
+	}
+
+	return exposed
 }
 
 type errorResponse struct {
@@ -45,14 +127,11 @@ type errorResponse struct {
 	Err     errorPayload `json:"error"`
 }
 
-func newErrorResponse(ctx context.Context, err error) errorResponse {
+func newErrorResponse(ctx context.Context, params ErrorHandlerParams, err error) errorResponse {
 	return errorResponse{
 		TraceID: getTraceID(trace.SpanFromContext(ctx)),
-		status:  kindToHTTPStatusCode(errors.Kind(err)),
-		Err: errorPayload{
-			Code:    errors.Code(err),
-			Message: err.Error(),
-		},
+		status:  httpStatusCode(err),
+		Err:     newErrorPayload(params, err),
 	}
 }
 
@@ -67,24 +146,15 @@ type errorListResponse struct {
 	Errs    []errorPayload `json:"errors"`
 }
 
-func newErrorListResponse(ctx context.Context, errs ...error) errorListResponse {
-	if len(errs) == 0 {
-		return errorListResponse{
-			status: 500,
-		}
-	}
-
+func newErrorListResponse(ctx context.Context, params ErrorHandlerParams, multiErr *errors.MultiError) errorListResponse {
 	errsPayload := []errorPayload{}
-	for _, err := range errs {
-		errsPayload = append(errsPayload, errorPayload{
-			Code:    errors.Code(err),
-			Message: err.Error(),
-		})
+	for _, err := range multiErr.Errors() {
+		errsPayload = append(errsPayload, newErrorPayload(params, err))
 	}
 
 	return errorListResponse{
 		TraceID: getTraceID(trace.SpanFromContext(ctx)),
-		status:  kindToHTTPStatusCode(errors.Kind(errs[0])),
+		status:  httpStatusCode(multiErr),
 		Errs:    errsPayload,
 	}
 }
@@ -93,6 +163,116 @@ func (e errorListResponse) StatusCode() int {
 	return e.status
 }
 
+// acceptsProblemDetails checks if problem details should be served, based on the quality values of the request's Accept header.
+// They are served when application/problem+json is acceptable (its quality is not zero), unless application/json is preferred.
+func acceptsProblemDetails(ctx *gin.Context) bool {
+	accept := ctx.GetHeader("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+
+	quality := acceptQuality(accept, MIMEProblemJSON)
+	return quality > 0 && quality >= acceptQuality(accept, binding.MIMEJSON)
+}
+
+// acceptQuality returns the quality value of the most specific media range of the Accept header that matches the given media type.
+// It returns zero when there's none.
+func acceptQuality(accept, mediaType string) float64 {
+	quality, specificity := 0.0, -1
+	for _, accepted := range strings.Split(accept, ",") {
+		params := strings.Split(accepted, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+		rangeSpecificity := -1
+		switch {
+		case mediaRange == mediaType:
+			rangeSpecificity = 2
+		case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+			rangeSpecificity = 1
+		case mediaRange == "*/*":
+			rangeSpecificity = 0
+		}
+
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		quality, specificity = 1, rangeSpecificity
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = q
+			}
+		}
+	}
+
+	return quality
+}
+
+// problemDetailsResponse is a RFC 7807 problem details document.
+// Besides the standard members, it carries the error code and trace id as extensions, as well as the exposed fields and violations of
+// single errors or, for aggregated errors, the error list.
+type problemDetailsResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	TraceID    string                  `json:"trace_id,omitempty"`
+	Code       errors.CodeType         `json:"code,omitempty"`
+	Details    map[string]any          `json:"details,omitempty"`
+	Violations []errors.FieldViolation `json:"violations,omitempty"`
+	Errs       []errorPayload          `json:"errors,omitempty"`
+}
+
+func newProblemDetailsResponse(ctx *gin.Context, params ErrorHandlerParams, multiErr *errors.MultiError) problemDetailsResponse {
+	baseURI := params.ProblemTypeBaseURI
+	if baseURI == "" {
+		baseURI = DefaultProblemTypeBaseURI
+	}
+
+	status := httpStatusCode(multiErr)
+
+	// Non-standard and custom mapped status codes have no status text, but problem titles can't be empty.
+	title := http.StatusText(status)
+	if title == "" {
+		title = string(errors.Kind(multiErr))
+	}
+
+	res := problemDetailsResponse{
+		Type:     baseURI + string(errors.Code(multiErr)),
+		Title:    title,
+		Status:   status,
+		Detail:   errors.PublicMessage(multiErr),
+		Instance: ctx.Request.URL.Path,
+		TraceID:  getTraceID(trace.SpanFromContext(ctx)),
+		Code:     errors.Code(multiErr),
+	}
+
+	// Details and violations of aggregated errors are only rendered in the error list, so they're not duplicated.
+	errs := multiErr.Errors()
+	if len(errs) == 1 {
+		res.Details = exposedFields(params, errs[0])
+		res.Violations = errors.Violations(errs[0])
+		return res
+	}
+
+	for _, err := range errs {
+		res.Errs = append(res.Errs, newErrorPayload(params, err))
+	}
+
+	return res
+}
+
+func (p problemDetailsResponse) StatusCode() int {
+	return p.Status
+}
+
 func getTraceID(span trace.Span) string {
 	if !span.SpanContext().HasTraceID() {
 		return ""
@@ -101,6 +281,15 @@ func getTraceID(span trace.Span) string {
 	return span.SpanContext().TraceID().String()
 }
 
+// httpStatusCode returns the HTTP status code registered for the given error (see errors.RegisterStatusMapping).
+// If there's none, the default status code of its kind is returned.
+func httpStatusCode(err error) int {
+	if status, ok := errors.HTTPStatus(err); ok {
+		return status
+	}
+	return kindToHTTPStatusCode(errors.Kind(err))
+}
+
 func kindToHTTPStatusCode(kind errors.KindType) int {
 	switch kind {
 	case errors.KindInvalidInput:
@@ -119,6 +308,16 @@ func kindToHTTPStatusCode(kind errors.KindType) int {
 		return http.StatusInternalServerError
 	case errors.KindResourceExhausted:
 		return http.StatusTooManyRequests
+	case errors.KindTimeout:
+		return http.StatusGatewayTimeout
+	case errors.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case errors.KindCanceled:
+		return StatusClientClosedRequest
+	case errors.KindFailedPrecondition:
+		return http.StatusPreconditionFailed
+	case errors.KindNotImplemented:
+		return http.StatusNotImplemented
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func newErrorHandlerTestRouter(params ErrorHandlerParams, errs ...error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(NewErrorHandler(params))
+	router.GET("/orders/42", func(ctx *gin.Context) {
+		for _, err := range errs {
+			_ = ctx.Error(err)
+		}
+	})
+
+	return router
+}
+
+func TestErrorHandler(t *testing.T) {
+	notFoundErr := errors.New("order 42 not found in database").
+		WithKind(errors.KindNotFound).
+		WithCode("ORDER_NOT_FOUND").
+		WithPublicMessage("order not found").
+		WithField("order_id", 42)
+
+	invalidErr := errors.New("invalid order").
+		WithKind(errors.KindInvalidInput).
+		WithCode("INVALID_ORDER").
+		WithViolations(errors.FieldViolation{Field: "items", Rule: "required", Message: "items are required"})
+
+	tt := []struct {
+		desc                string
+		params              ErrorHandlerParams
+		accept              string
+		errs                []error
+		expectedStatus      int
+		expectedContentType string
+		expectedRetryAfter  string
+		expectedBody        string
+	}{
+		{
+			desc:                "should render a single error as JSON",
+			params:              ErrorHandlerParams{ExposedFields: []string{"order_id"}},
+			accept:              "application/problem+json",
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"ORDER_NOT_FOUND","message":"order not found","details":{"order_id":42}}}`,
+		},
+		{
+			desc:                "should render error lists as JSON",
+			errs:                []error{invalidErr, notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"errors":[{"code":"INVALID_ORDER","message":"invalid order","violations":[{"field":"items","rule":"required","message":"items are required"}]},{"code":"ORDER_NOT_FOUND","message":"order not found"}]}`,
+		},
+		{
+			desc:                "should render problem details when there's no Accept header",
+			params:              ErrorHandlerParams{ProblemDetails: true, ExposedFields: []string{"order_id"}},
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: MIMEProblemJSON,
+			expectedBody:        `{"type":"urn:problem-type:ORDER_NOT_FOUND","title":"Not Found","status":404,"detail":"order not found","instance":"/orders/42","code":"ORDER_NOT_FOUND","details":{"order_id":42}}`,
+		},
+		{
+			desc:                "should render problem details with the given type base URI",
+			params:              ErrorHandlerParams{ProblemDetails: true, ProblemTypeBaseURI: "https://example.com/problems/"},
+			accept:              "application/problem+json",
+			errs:                []error{invalidErr},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: MIMEProblemJSON,
+			expectedBody:        `{"type":"https://example.com/problems/INVALID_ORDER","title":"Bad Request","status":400,"detail":"invalid order","instance":"/orders/42","code":"INVALID_ORDER","violations":[{"field":"items","rule":"required","message":"items are required"}]}`,
+		},
+		{
+			desc:                "should render violations of aggregated errors only in the problem details error list",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			accept:              "*/*",
+			errs:                []error{invalidErr, notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: MIMEProblemJSON,
+			expectedBody:        `{"type":"urn:problem-type:ORDER_NOT_FOUND","title":"Not Found","status":404,"detail":"invalid order; order not found","instance":"/orders/42","code":"ORDER_NOT_FOUND","errors":[{"code":"INVALID_ORDER","message":"invalid order","violations":[{"field":"items","rule":"required","message":"items are required"}]},{"code":"ORDER_NOT_FOUND","message":"order not found"}]}`,
+		},
+		{
+			desc:                "should fall back to the error kind as problem title when the status has no text",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			errs:                []error{errors.New("canceled").WithKind(errors.KindCanceled).WithCode("REQUEST_CANCELED")},
+			expectedStatus:      StatusClientClosedRequest,
+			expectedContentType: MIMEProblemJSON,
+			expectedBody:        `{"type":"urn:problem-type:REQUEST_CANCELED","title":"CANCELED","status":499,"detail":"canceled","instance":"/orders/42","code":"REQUEST_CANCELED"}`,
+		},
+		{
+			desc:                "should render JSON when it's preferred by the Accept header",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			accept:              "application/problem+json;q=0.1, application/json",
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"ORDER_NOT_FOUND","message":"order not found"}}`,
+		},
+		{
+			desc:                "should render problem details when they're preferred by the Accept header",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			accept:              "application/json;q=0.5, application/*;q=0.8",
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: MIMEProblemJSON,
+			expectedBody:        `{"type":"urn:problem-type:ORDER_NOT_FOUND","title":"Not Found","status":404,"detail":"order not found","instance":"/orders/42","code":"ORDER_NOT_FOUND"}`,
+		},
+		{
+			desc:                "should render JSON when problem details are not acceptable",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			accept:              "application/problem+json;q=0, */*",
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"ORDER_NOT_FOUND","message":"order not found"}}`,
+		},
+		{
+			desc:                "should inform clients when they can retry, rounding up to seconds",
+			errs:                []error{errors.New("too many requests").WithKind(errors.KindResourceExhausted).WithCode("RATE_LIMITED").WithRetryAfter(1500 * time.Millisecond)},
+			expectedStatus:      http.StatusTooManyRequests,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedRetryAfter:  "2",
+			expectedBody:        `{"error":{"code":"RATE_LIMITED","message":"too many requests"}}`,
+		},
+		{
+			desc:                "should render JSON when problem details have zero quality",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			accept:              "application/problem+json;q=0",
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"ORDER_NOT_FOUND","message":"order not found"}}`,
+		},
+		{
+			desc:                "should render JSON when neither JSON nor problem details are acceptable",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			accept:              "text/html",
+			errs:                []error{notFoundErr},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"ORDER_NOT_FOUND","message":"order not found"}}`,
+		},
+		{
+			desc:                "should not render anything when there are no errors",
+			params:              ErrorHandlerParams{ProblemDetails: true},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "",
+			expectedBody:        "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			rec := httptest.NewRecorder()
+			newErrorHandlerTestRouter(tc.params, tc.errs...).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status to be '%d': received '%d'", tc.expectedStatus, rec.Code)
+			}
+
+			if contentType := rec.Header().Get("Content-Type"); contentType != tc.expectedContentType {
+				t.Errorf("Expected Content-Type to be '%s': received '%s'", tc.expectedContentType, contentType)
+			}
+
+			if retryAfter := rec.Header().Get("Retry-After"); retryAfter != tc.expectedRetryAfter {
+				t.Errorf("Expected Retry-After to be '%s': received '%s'", tc.expectedRetryAfter, retryAfter)
+			}
+
+			if diff := cmp.Diff(tc.expectedBody, rec.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -50,7 +50,12 @@ func Logger(logger LogProvider) func(ctx *gin.Context) {
 
 		switch {
 		case statusCode >= 500:
-			logger.Error(lctx, errors.New(msg))
+			// Request errors are attached, since responses only expose their public messages.
+			errs := []error{}
+			for _, err := range ctx.Errors {
+				errs = append(errs, err.Err)
+			}
+			logger.Error(lctx, errors.New(msg).WithRootError(errors.Join(errs...)))
 			break
 		case statusCode >= 400:
 			logger.Warning(lctx, msg)
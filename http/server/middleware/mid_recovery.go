@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// Recovery creates a new Recovery middleware, that recovers from panics of the middlewares and handlers that come after it,
+// so it should come before them (only the Meter middleware should come first, so recovered panics are still measured).
+// The panic is turned into a CustomError of KindInternal (see errors.FromPanic), logged with the panic call stack,
+// and served as the ErrorHandler would do it, with the given parameters.
+// Since the panic interrupts the Logger middleware, the request is only logged by Recovery.
+func Recovery(logger LogProvider, params ...ErrorHandlerParams) func(ctx *gin.Context) {
+	p := ErrorHandlerParams{}
+	if len(params) > 0 {
+		p = params[0]
+	}
+
+	return func(ctx *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			// Panics used by net/http to abort responses must not be recovered.
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+
+			err := errors.FromPanic(r)
+			logger.Critical(ctx.Request.Context(), err)
+
+			_ = ctx.Error(err)
+			ctx.Abort()
+
+			// Responses partially written before the panic can't be replaced.
+			if !ctx.Writer.Written() {
+				renderErrors(ctx, p)
+			}
+		}()
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"context"
+	e "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/nonrecording"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// loggerMock records the errors logged by each level.
+type loggerMock struct {
+	errors    []error
+	criticals []error
+}
+
+func (l *loggerMock) Debug(ctx context.Context, msg string, args ...any)   {}
+func (l *loggerMock) Info(ctx context.Context, msg string, args ...any)    {}
+func (l *loggerMock) Warning(ctx context.Context, msg string, args ...any) {}
+func (l *loggerMock) Error(ctx context.Context, err error)                 { l.errors = append(l.errors, err) }
+func (l *loggerMock) Critical(ctx context.Context, err error)              { l.criticals = append(l.criticals, err) }
+
+// meterMock is a metric.Meter that records the measurements of synchronous integer instruments.
+type meterMock struct {
+	metric.Meter
+	measurements []measurementMock
+}
+
+type measurementMock struct {
+	name       string
+	value      int64
+	attributes []attribute.KeyValue
+}
+
+func newMeterMock() *meterMock {
+	return &meterMock{Meter: nonrecording.NewNoopMeter()}
+}
+
+func (m *meterMock) provider() metric.MeterProvider          { return meterProviderMock{meter: m} }
+func (m *meterMock) SyncInt64() syncint64.InstrumentProvider { return m }
+func (m *meterMock) instrument(name string) instrumentMock {
+	noop, _ := nonrecording.NewNoopMeter().SyncInt64().Counter(name)
+	return instrumentMock{Synchronous: noop, meter: m, name: name}
+}
+func (m *meterMock) Counter(name string, opts ...instrument.Option) (syncint64.Counter, error) {
+	return m.instrument(name), nil
+}
+func (m *meterMock) UpDownCounter(name string, opts ...instrument.Option) (syncint64.UpDownCounter, error) {
+	return m.instrument(name), nil
+}
+func (m *meterMock) Histogram(name string, opts ...instrument.Option) (syncint64.Histogram, error) {
+	return m.instrument(name), nil
+}
+
+type meterProviderMock struct {
+	meter *meterMock
+}
+
+func (p meterProviderMock) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return p.meter
+}
+
+// instrumentMock records the measurements of counters and histograms into its meter.
+type instrumentMock struct {
+	instrument.Synchronous
+	meter *meterMock
+	name  string
+}
+
+func (i instrumentMock) Add(ctx context.Context, value int64, attributes ...attribute.KeyValue) {
+	i.meter.measurements = append(i.meter.measurements, measurementMock{name: i.name, value: value, attributes: attributes})
+}
+func (i instrumentMock) Record(ctx context.Context, value int64, attributes ...attribute.KeyValue) {
+	i.Add(ctx, value, attributes...)
+}
+
+func TestRecovery(t *testing.T) {
+	panicking := func(ctx *gin.Context) {
+		panic("mocked panic")
+	}
+
+	tt := []struct {
+		desc                string
+		middlewares         func(logger LogProvider) []gin.HandlerFunc
+		handler             gin.HandlerFunc
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+		expectedCriticals   int
+	}{
+		{
+			desc: "should recover from handler panics and log them once",
+			middlewares: func(logger LogProvider) []gin.HandlerFunc {
+				return Default(DefaultInput{ApplicationName: "test", Logger: logger})
+			},
+			handler:             panicking,
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"PANIC_RECOVERED","message":"an internal error has occurred"}}`,
+			expectedCriticals:   1,
+		},
+		{
+			desc: "should recover from middleware panics",
+			middlewares: func(logger LogProvider) []gin.HandlerFunc {
+				return []gin.HandlerFunc{Recovery(logger), Logger(logger), NewErrorHandler(ErrorHandlerParams{}), panicking}
+			},
+			handler:             func(ctx *gin.Context) {},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":{"code":"PANIC_RECOVERED","message":"an internal error has occurred"}}`,
+			expectedCriticals:   1,
+		},
+		{
+			desc: "should render recovered panics with the given error handler parameters",
+			middlewares: func(logger LogProvider) []gin.HandlerFunc {
+				return []gin.HandlerFunc{Recovery(logger, ErrorHandlerParams{ProblemDetails: true})}
+			},
+			handler:             panicking,
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: MIMEProblemJSON,
+			expectedBody:        `{"type":"urn:problem-type:PANIC_RECOVERED","title":"Internal Server Error","status":500,"detail":"an internal error has occurred","instance":"/","code":"PANIC_RECOVERED"}`,
+			expectedCriticals:   1,
+		},
+		{
+			desc: "should keep responses written before the panic",
+			middlewares: func(logger LogProvider) []gin.HandlerFunc {
+				return []gin.HandlerFunc{Recovery(logger)}
+			},
+			handler: func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "partial")
+				panic("mocked panic")
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "partial",
+			expectedCriticals:   1,
+		},
+		{
+			desc: "should not log requests that don't panic",
+			middlewares: func(logger LogProvider) []gin.HandlerFunc {
+				return []gin.HandlerFunc{Recovery(logger)}
+			},
+			handler:             func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) },
+			expectedStatus:      http.StatusNoContent,
+			expectedContentType: "",
+			expectedBody:        "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := &loggerMock{}
+
+			router := gin.New()
+			router.Use(tc.middlewares(logger)...)
+			router.GET("/", tc.handler)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status to be '%d': received '%d'", tc.expectedStatus, rec.Code)
+			}
+
+			if contentType := rec.Header().Get("Content-Type"); contentType != tc.expectedContentType {
+				t.Errorf("Expected Content-Type to be '%s': received '%s'", tc.expectedContentType, contentType)
+			}
+
+			if diff := cmp.Diff(tc.expectedBody, rec.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+
+			if len(logger.criticals) != tc.expectedCriticals || len(logger.errors) != 0 {
+				t.Fatalf("Expected %d critical and no error logs: received %d and %d", tc.expectedCriticals, len(logger.criticals), len(logger.errors))
+			}
+
+			for _, err := range logger.criticals {
+				if !e.Is(err, errors.ErrPanicRecovered) || errors.Stack(err) == nil {
+					t.Errorf("Expected logged error to be a recovered panic with its call stack, got %v", err)
+				}
+			}
+		})
+	}
+
+	t.Run("should meter recovered panics", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		meter := newMeterMock()
+		global.SetMeterProvider(meter.provider())
+
+		router := gin.New()
+		router.Use(Default(DefaultInput{ApplicationName: "test", Logger: &loggerMock{}})...)
+		router.GET("/", panicking)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		var requests []measurementMock
+		for _, measurement := range meter.measurements {
+			if measurement.name == requestCounterName {
+				requests = append(requests, measurement)
+			}
+		}
+
+		if len(requests) != 1 {
+			t.Fatalf("Expected a single request to be counted: received %d", len(requests))
+		}
+
+		statusCode := attribute.Int("status_code", http.StatusInternalServerError)
+		for _, attr := range requests[0].attributes {
+			if attr == statusCode {
+				return
+			}
+		}
+		t.Errorf("Expected request to be counted with attribute '%v', got %v", statusCode, requests[0].attributes)
+	})
+
+	t.Run("should not recover from aborted handlers", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+
+		router := gin.New()
+		router.Use(Recovery(&loggerMock{}))
+		router.GET("/", func(ctx *gin.Context) {
+			panic(http.ErrAbortHandler)
+		})
+
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("Expected http.ErrAbortHandler panic, got %v", r)
+			}
+		}()
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
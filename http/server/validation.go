@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+// NewFieldViolations translates the errors produced during gin request binding into FieldViolations.
+// When the bound target is given, field paths use the names sent by clients (taken from its json or form tags), like "items[0].name".
+// Otherwise, they use the names reported by the validator, which are the Go field names unless a tag name function is registered.
+// It should be used to detail ErrRequestBodyValidation and ErrRequestQueryStringValidation:
+//
+//	if err := ctx.ShouldBindJSON(&body); err != nil {
+//		ctx.Error(server.ErrRequestBodyValidation.WithRootError(err).WithViolations(server.NewFieldViolations(err, &body)...))
+//	}
+func NewFieldViolations(err error, target ...any) []errors.FieldViolation {
+	violations := []errors.FieldViolation{}
+
+	var validationErrs validator.ValidationErrors
+	if e.As(err, &validationErrs) {
+		for _, fieldErr := range validationErrs {
+			field := fieldPath(fieldErr.Namespace())
+			if len(target) > 0 && target[0] != nil {
+				field = clientFieldPath(reflect.TypeOf(target[0]), fieldPath(fieldErr.StructNamespace()))
+			}
+
+			violations = append(violations, errors.FieldViolation{
+				Field:   field,
+				Rule:    fieldErr.Tag(),
+				Message: fmt.Sprintf("%s failed on the '%s' rule", field, fieldErr.Tag()),
+			})
+		}
+		return violations
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if e.As(err, &typeErr) {
+		violations = append(violations, errors.FieldViolation{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type.String()),
+		})
+	}
+
+	return violations
+}
+
+// fieldPath removes the root struct name from a validator namespace, like "Request.Items[0].Name".
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// clientFieldPath translates a path of Go field names of the given type, like "Items[0].Name", into the names clients use.
+// Segments that can't be resolved are kept as they are.
+func clientFieldPath(t reflect.Type, path string) string {
+	segments := []string{}
+	for _, segment := range strings.Split(path, ".") {
+		name, index := segment, ""
+		if i := strings.Index(segment, "["); i >= 0 {
+			name, index = segment[:i], segment[i:]
+		}
+
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t == nil || t.Kind() != reflect.Struct {
+			t = nil
+			segments = append(segments, segment)
+			continue
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok {
+			t = nil
+			segments = append(segments, segment)
+			continue
+		}
+
+		t = field.Type
+		for i := strings.Count(index, "["); i > 0 && t != nil; i-- {
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				t = nil
+			}
+		}
+
+		clientName := fieldName(field)
+		if clientName == "" {
+			// Embedded structs without names are flattened by encoding/json, so they're not part of the path.
+			if field.Anonymous && index == "" {
+				continue
+			}
+			clientName = name
+		}
+		segments = append(segments, clientName+index)
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// fieldName returns the name clients use for the given struct field, taken from its json or form tags.
+// It returns an empty string when neither tag names the field.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
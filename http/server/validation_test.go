@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+type validationTestItem struct {
+	Name     string `json:"name" binding:"required"`
+	Quantity int    `json:"quantity,omitempty" binding:"min=1"`
+}
+
+type validationTestAudit struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type validationTestRequest struct {
+	validationTestAudit
+	UserID  string               `json:"user_id" binding:"required"`
+	Note    string               `binding:"max=3"`
+	Items   []validationTestItem `json:"items" binding:"required,dive"`
+	Address struct {
+		ZipCode string `json:"zip_code" binding:"len=8"`
+	} `json:"address"`
+}
+
+func TestNewFieldViolations(t *testing.T) {
+	tt := []struct {
+		desc      string
+		body      string
+		useTarget bool
+		expected  []errors.FieldViolation
+	}{
+		{
+			desc:      "should use json field names of nested, embedded and renamed fields",
+			body:      `{"note":"long note","items":[{"name":"item","quantity":1},{"quantity":0}],"address":{"zip_code":"123"}}`,
+			useTarget: true,
+			expected: []errors.FieldViolation{
+				{Field: "reason", Rule: "required", Message: "reason failed on the 'required' rule"},
+				{Field: "user_id", Rule: "required", Message: "user_id failed on the 'required' rule"},
+				{Field: "Note", Rule: "max", Message: "Note failed on the 'max' rule"},
+				{Field: "items[1].name", Rule: "required", Message: "items[1].name failed on the 'required' rule"},
+				{Field: "items[1].quantity", Rule: "min", Message: "items[1].quantity failed on the 'min' rule"},
+				{Field: "address.zip_code", Rule: "len", Message: "address.zip_code failed on the 'len' rule"},
+			},
+		},
+		{
+			desc: "should use the validator field names when the target is not given",
+			body: `{"reason":"audit","user_id":"42","items":[{"name":"item","quantity":0}],"address":{"zip_code":"12345678"}}`,
+			expected: []errors.FieldViolation{
+				{Field: "Items[0].Quantity", Rule: "min", Message: "Items[0].Quantity failed on the 'min' rule"},
+			},
+		},
+		{
+			desc: "should describe type errors",
+			body: `{"user_id":42}`,
+			expected: []errors.FieldViolation{
+				{Field: "user_id", Rule: "type", Message: "user_id must be of type string"},
+			},
+		},
+		{
+			desc:     "should return no violations for any other error",
+			body:     `{`,
+			expected: []errors.FieldViolation{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+
+			var body validationTestRequest
+			err := binding.JSON.Bind(req, &body)
+			if err == nil {
+				t.Fatal("Expected a binding error")
+			}
+
+			violations := NewFieldViolations(err)
+			if tc.useTarget {
+				violations = NewFieldViolations(err, &body)
+			}
+
+			if diff := cmp.Diff(tc.expected, violations); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
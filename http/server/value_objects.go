@@ -8,16 +8,20 @@ import (
 
 var (
 	// ErrRequestQueryStringValidation indicates a failure during request query string binding.
-	ErrRequestQueryStringValidation errors.CustomError = errors.
-					New("request query string validation failed").
-					WithKind(errors.KindInvalidInput).
-					WithCode("ERR_REQUEST_QUERY_STRING_VALIDATION")
+	ErrRequestQueryStringValidation errors.CustomError = errors.MustRegister(errors.CodeDefinition{
+		Code:        "ERR_REQUEST_QUERY_STRING_VALIDATION",
+		Kind:        errors.KindInvalidInput,
+		Message:     "request query string validation failed",
+		Description: "The request query string is invalid. The invalid fields are detailed by its violations.",
+	})
 
 	// ErrRequestBodyValidation indicates a failure during request body binding.
-	ErrRequestBodyValidation errors.CustomError = errors.
-					New("request query string validation failed").
-					WithKind(errors.KindInvalidInput).
-					WithCode("ERR_REQUEST_BODY_VALIDATION")
+	ErrRequestBodyValidation errors.CustomError = errors.MustRegister(errors.CodeDefinition{
+		Code:        "ERR_REQUEST_BODY_VALIDATION",
+		Kind:        errors.KindInvalidInput,
+		Message:     "request body validation failed",
+		Description: "The request body is invalid. The invalid fields are detailed by its violations.",
+	})
 )
 
 type messageResponse struct {
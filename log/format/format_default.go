@@ -34,6 +34,15 @@ func (b defaultLogFormatter) Format(ctx context.Context, in LogInput) any {
 		attrs[LogAttributeRootError] = errors.RootError(in.Err)
 		attrs[LogAttributeErrorKind] = string(errors.Kind(in.Err))
 		attrs[LogAttributeErrorCode] = string(errors.Code(in.Err))
+		mergeErrorFields(attrs, in.Err)
+
+		if errs := buildErrorList(in.Err); errs != nil {
+			payload["errors"] = errs
+		}
+
+		if stack := errors.Stack(in.Err); stack != nil {
+			payload["stack_trace"] = stack.String()
+		}
 	}
 	if len(attrs) > 0 {
 		payload["attributes"] = attrs
@@ -73,6 +73,11 @@ func (b gcpCloudLoggingLogFormatter) Format(ctx context.Context, in LogInput) an
 		attrs[LogAttributeRootError] = errors.RootError(in.Err)
 		attrs[LogAttributeErrorKind] = string(errors.Kind(in.Err))
 		attrs[LogAttributeErrorCode] = string(errors.Code(in.Err))
+		mergeErrorFields(attrs, in.Err)
+
+		if errs := buildErrorList(in.Err); errs != nil {
+			payload["errors"] = errs
+		}
 
 		// Necessary to link error to Cloud Error Reporting.
 		// More details in: https://cloud.google.com/error-reporting/docs/formatting-error-messages
@@ -81,6 +86,12 @@ func (b gcpCloudLoggingLogFormatter) Format(ctx context.Context, in LogInput) an
 			"service": b.applicationName,
 			"version": b.applicationVersion,
 		}
+
+		// Error Reporting groups errors by their stack traces, which must follow the Go runtime format.
+		// More details in: https://cloud.google.com/error-reporting/docs/formatting-error-messages#log-error
+		if stack := errors.Stack(in.Err); stack != nil {
+			payload["stack_trace"] = fmt.Sprintf("%s\n\ngoroutine 1 [running]:\n%s", in.Message, stack.String())
+		}
 	}
 	if len(attrs) > 0 {
 		payload["logging.googleapis.com/labels"] = attrs
@@ -0,0 +1,85 @@
+package format
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+type textLogFormatter struct{}
+
+// NewText creates a new LogFormatter that produces human-readable lines, like:
+//
+//	2021-01-01T12:00:00Z ERROR could not process order err_code=ORDER_NOT_FOUND order_id=42
+//
+// Unlike the default and GCP formatters, it doesn't record logs into the current span,
+// so it can be combined with them by Logger sinks without duplicating span events.
+func NewText() *textLogFormatter {
+	return &textLogFormatter{}
+}
+
+// Format formats the log payload that will be rendered, as a Line.
+func (f textLogFormatter) Format(ctx context.Context, in LogInput) any {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s", in.Timestamp.Format(time.RFC3339), in.Level, in.Message)
+
+	attrs := extractLogAttributesFromContext(ctx, in.Attributes)
+	if in.Err != nil {
+		attrs[LogAttributeRootError] = errors.RootError(in.Err)
+		attrs[LogAttributeErrorKind] = string(errors.Kind(in.Err))
+		attrs[LogAttributeErrorCode] = string(errors.Code(in.Err))
+		mergeErrorFields(attrs, in.Err)
+	}
+
+	if spanContext := trace.SpanFromContext(ctx).SpanContext(); spanContext.TraceID().IsValid() {
+		attrs["trace_id"] = spanContext.TraceID().String()
+		attrs["span_id"] = spanContext.SpanID().String()
+	}
+
+	if in.Payload != nil {
+		attrs["payload"] = in.Payload
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%s", key, formatTextValue(attrs[LogAttribute(key)]))
+	}
+
+	if in.Err != nil {
+		if stack := errors.Stack(in.Err); stack != nil {
+			fmt.Fprintf(&b, "\n%s", stack.String())
+		}
+	}
+
+	return Line(b.String())
+}
+
+// formatTextValue renders strings as they are, quoting them only when needed, and any other value as JSON.
+func formatTextValue(value any) string {
+	s, ok := value.(string)
+	if !ok {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return strconv.Quote(fmt.Sprint(value))
+		}
+		return string(data)
+	}
+
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
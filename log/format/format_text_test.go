@@ -0,0 +1,85 @@
+package format
+
+import (
+	"context"
+	e "errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/trivelaapp/go-kit/errors"
+)
+
+func TestTextFormat(t *testing.T) {
+	timestamp := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		desc     string
+		ctx      context.Context
+		in       LogInput
+		expected Line
+	}{
+		{
+			desc:     "should format the timestamp, level and message",
+			ctx:      context.Background(),
+			in:       LogInput{Level: "INFO", Message: "order processed", Timestamp: timestamp},
+			expected: "2021-01-01T12:00:00Z INFO order processed",
+		},
+		{
+			desc: "should include context attributes sorted by key",
+			ctx:  context.WithValue(context.WithValue(context.Background(), "user_id", "42"), "http.method", "GET"),
+			in: LogInput{
+				Level:      "INFO",
+				Message:    "request",
+				Attributes: LogAttributeSet{"user_id": true, "http.method": true, "missing": true},
+				Timestamp:  timestamp,
+			},
+			expected: "2021-01-01T12:00:00Z INFO request http.method=GET user_id=42",
+		},
+		{
+			desc: "should quote strings only when needed",
+			ctx: context.WithValue(context.WithValue(context.WithValue(context.Background(),
+				"query", "a=b"), "agent", "go kit"), "referer", ""),
+			in: LogInput{
+				Level:      "DEBUG",
+				Message:    "request",
+				Attributes: LogAttributeSet{"query": true, "agent": true, "referer": true},
+				Payload:    `say "hi"`,
+				Timestamp:  timestamp,
+			},
+			expected: `2021-01-01T12:00:00Z DEBUG request agent="go kit" payload="say \"hi\"" query="a=b" referer=""`,
+		},
+		{
+			desc:     "should encode non string payloads as JSON",
+			ctx:      context.Background(),
+			in:       LogInput{Level: "INFO", Message: "order", Payload: map[string]any{"id": 42, "items": []string{"a", "b"}}, Timestamp: timestamp},
+			expected: `2021-01-01T12:00:00Z INFO order payload={"id":42,"items":["a","b"]}`,
+		},
+		{
+			desc: "should include error attributes and fields",
+			ctx:  context.Background(),
+			in: LogInput{
+				Level:   "ERROR",
+				Message: "could not process order",
+				Err: errors.New("could not process order").
+					WithKind(errors.KindNotFound).
+					WithCode("ORDER_NOT_FOUND").
+					WithField("order_id", 42).
+					WithRootError(e.New("connection refused")),
+				Timestamp: timestamp,
+			},
+			expected: `2021-01-01T12:00:00Z ERROR could not process order err_code=ORDER_NOT_FOUND err_kind=NOT_FOUND order_id=42 root_error="connection refused"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			res := NewText().Format(tc.ctx, tc.in)
+
+			if diff := cmp.Diff(tc.expected, res); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 
+	"github.com/trivelaapp/go-kit/errors"
 	grpc_interceptor "github.com/trivelaapp/go-kit/grpc/server/interceptor/logging"
 	"github.com/trivelaapp/go-kit/http/server/middleware"
 )
@@ -22,6 +23,9 @@ type LogInput struct {
 	Timestamp  time.Time
 }
 
+// Line is a log payload that is written as it is, instead of being encoded as JSON, like the ones produced by NewText.
+type Line string
+
 // LogAttribute represents an information to be extracted from the context and included into the log.
 type LogAttribute string
 
@@ -34,6 +38,9 @@ const (
 
 	// LogAttributeErrorCode defines the name of the ErrorCode attribute attached into logs.
 	LogAttributeErrorCode LogAttribute = "err_code"
+
+	// LogAttributeErrorMessage defines the name of the ErrorMessage attribute attached into logs.
+	LogAttributeErrorMessage LogAttribute = "err_message"
 )
 
 // LogAttributeSet is a set of LogAttributes.
@@ -87,10 +94,41 @@ func extractLogAttributesFromContext(ctx context.Context, attrSet LogAttributeSe
 	return attributes
 }
 
+// mergeErrorFields includes the key/value details attached to the error as log attributes.
+// Attributes already extracted from the context are not overwritten.
+func mergeErrorFields(attrs map[LogAttribute]any, err error) {
+	for key, value := range errors.Fields(err) {
+		if _, ok := attrs[LogAttribute(key)]; !ok {
+			attrs[LogAttribute(key)] = value
+		}
+	}
+}
+
+// buildErrorList describes each error aggregated by a MultiError, even when wrapped, so they can be individually inspected.
+// It returns nil for any other error.
+func buildErrorList(err error) []map[LogAttribute]any {
+	errs := errors.Errors(err)
+	if errs == nil {
+		return nil
+	}
+
+	list := []map[LogAttribute]any{}
+	for _, err := range errs {
+		list = append(list, map[LogAttribute]any{
+			LogAttributeErrorMessage: err.Error(),
+			LogAttributeRootError:    errors.RootError(err),
+			LogAttributeErrorKind:    string(errors.Kind(err)),
+			LogAttributeErrorCode:    string(errors.Code(err)),
+		})
+	}
+
+	return list
+}
+
 func buildOtelAttributes(attrs map[LogAttribute]any, prefix string) []attribute.KeyValue {
 	eAttrs := []attribute.KeyValue{}
 	for k, v := range attrs {
-		eAttrs = append(eAttrs, attribute.String(fmt.Sprintf("%s.%s", prefix, k), v.(string)))
+		eAttrs = append(eAttrs, attribute.String(fmt.Sprintf("%s.%s", prefix, k), fmt.Sprint(v)))
 	}
 
 	return eAttrs
@@ -37,3 +37,6 @@ require (
 	google.golang.org/protobuf v1.28.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
+
+// The errors module is resolved from this repository, since its latest APIs are not released yet.
+replace github.com/trivelaapp/go-kit/errors => ../errors
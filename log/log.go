@@ -23,6 +23,8 @@ type LoggerParams struct {
 	Level      string
 	Formatter  LogFormatter
 	Attributes format.LogAttributeSet
+	// Sinks are the destinations of log entries, each one with its own level and formatter. Defaults to stdout.
+	Sinks []Sink
 }
 
 // Logger is the structure responsible for log data.
@@ -30,6 +32,7 @@ type Logger struct {
 	level      Level
 	formatter  LogFormatter
 	attributes format.LogAttributeSet
+	sinks      []sink
 	now        func() time.Time
 }
 
@@ -50,6 +53,23 @@ func NewLogger(params LoggerParams) *Logger {
 		logger.formatter = format.NewDefault()
 	}
 
+	sinks := params.Sinks
+	if len(sinks) == 0 {
+		sinks = []Sink{{}}
+	}
+
+	// The Logger level is the most verbose level among its sinks, so entries are only discarded when no sink accepts them.
+	defaultLevel := logger.level
+	logger.level = 0
+	locks := writerLocks{}
+	for _, s := range sinks {
+		sink := newSink(s, defaultLevel, logger.formatter, locks)
+		if sink.level > logger.level {
+			logger.level = sink.level
+		}
+		logger.sinks = append(logger.sinks, sink)
+	}
+
 	return logger
 }
 
@@ -117,41 +137,32 @@ func (l Logger) JSON(ctx context.Context, data any, logLevel ...Level) {
 }
 
 func (l Logger) printMsg(ctx context.Context, msg string, level Level) {
-	payload := l.formatter.Format(ctx, format.LogInput{
+	l.print(ctx, format.LogInput{
 		Level:      level.String(),
 		Message:    msg,
 		Attributes: l.attributes,
 		Timestamp:  l.now(),
-	})
-
-	data, _ := json.Marshal(payload)
-	fmt.Println(string(data))
+	}, level)
 }
 
 func (l Logger) printJSON(ctx context.Context, jsonData any, level Level) {
-	payload := l.formatter.Format(ctx, format.LogInput{
+	l.print(ctx, format.LogInput{
 		Level:      level.String(),
 		Message:    "JSON data logged",
 		Payload:    jsonData,
 		Attributes: l.attributes,
 		Timestamp:  l.now(),
-	})
-
-	data, _ := json.Marshal(payload)
-	fmt.Println(string(data))
+	}, level)
 }
 
 func (l Logger) printError(ctx context.Context, err error, level Level) {
-	payload := l.formatter.Format(ctx, format.LogInput{
+	l.print(ctx, format.LogInput{
 		Level:      level.String(),
 		Message:    err.Error(),
 		Err:        err,
 		Attributes: l.attributes,
 		Timestamp:  l.now(),
-	})
-
-	data, _ := json.Marshal(payload)
-	fmt.Println(string(data))
+	}, level)
 
 	counter := errorCounter()
 	if counter != nil {
@@ -1,16 +1,21 @@
 package log
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 
+	kit_errors "github.com/trivelaapp/go-kit/errors"
 	"github.com/trivelaapp/go-kit/log/format"
 )
 
@@ -342,6 +347,27 @@ func TestError(t *testing.T) {
 			attrs:       format.LogAttributeSet{"attr1": true},
 			expectedLog: `{"attributes":{"attr1":"value1","err_code":"UNKNOWN","err_kind":"UNEXPECTED","root_error":"random error"},"level":"ERROR","message":"random error","timestamp":"2020-12-01T12:00:00Z"}`,
 		},
+		{
+			desc:        "should log with error fields",
+			ctx:         ctx,
+			level:       "DEBUG",
+			err:         kit_errors.New("random error").WithField("order_id", "123"),
+			expectedLog: `{"attributes":{"err_code":"UNKNOWN","err_kind":"UNEXPECTED","order_id":"123","root_error":"random error"},"level":"ERROR","message":"random error","timestamp":"2020-12-01T12:00:00Z"}`,
+		},
+		{
+			desc:        "should log aggregated errors",
+			ctx:         ctx,
+			level:       "DEBUG",
+			err:         kit_errors.Join(errors.New("first error"), kit_errors.New("second error").WithKind(kit_errors.KindNotFound)),
+			expectedLog: `{"attributes":{"err_code":"UNKNOWN","err_kind":"UNEXPECTED","root_error":"first error; second error"},"errors":[{"err_code":"UNKNOWN","err_kind":"UNEXPECTED","err_message":"first error","root_error":"first error"},{"err_code":"UNKNOWN","err_kind":"NOT_FOUND","err_message":"second error","root_error":"second error"}],"level":"ERROR","message":"first error; second error","timestamp":"2020-12-01T12:00:00Z"}`,
+		},
+		{
+			desc:        "should log wrapped aggregated errors",
+			ctx:         ctx,
+			level:       "DEBUG",
+			err:         fmt.Errorf("wrapped: %w", kit_errors.Join(errors.New("first error"), errors.New("second error"))),
+			expectedLog: `{"attributes":{"err_code":"UNKNOWN","err_kind":"UNEXPECTED","root_error":"first error; second error"},"errors":[{"err_code":"UNKNOWN","err_kind":"UNEXPECTED","err_message":"first error","root_error":"first error"},{"err_code":"UNKNOWN","err_kind":"UNEXPECTED","err_message":"second error","root_error":"second error"}],"level":"ERROR","message":"wrapped: first error; second error","timestamp":"2020-12-01T12:00:00Z"}`,
+		},
 	}
 
 	for _, tc := range tt {
@@ -504,3 +530,126 @@ func captureOutput(output func()) string {
 
 	return strings.TrimRight(string(out), "\n")
 }
+
+func TestSinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should write entries to every sink that accepts their level, using the sink formatter", func(t *testing.T) {
+		var jsonOut, textOut bytes.Buffer
+
+		logger := NewLogger(LoggerParams{
+			Level: "INFO",
+			Sinks: []Sink{
+				{Writer: &jsonOut, Level: "WARNING"},
+				{Writer: &textOut, Level: "DEBUG", Formatter: format.NewText()},
+			},
+		})
+		logger.now = mockedTimmer()
+
+		logger.Debug(ctx, "debug message")
+		logger.Warning(ctx, "warning message")
+		logger.Error(ctx, kit_errors.New("could not process order").WithCode("ORDER_NOT_FOUND").WithField("order_id", 42))
+
+		expectedJSON := `{"level":"WARNING","message":"warning message","timestamp":"2020-12-01T12:00:00Z"}
+{"attributes":{"err_code":"ORDER_NOT_FOUND","err_kind":"UNEXPECTED","order_id":42,"root_error":"could not process order"},"level":"ERROR","message":"could not process order","timestamp":"2020-12-01T12:00:00Z"}
+`
+		if diff := cmp.Diff(expectedJSON, jsonOut.String()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+
+		expectedText := `2020-12-01T12:00:00Z DEBUG debug message
+2020-12-01T12:00:00Z WARNING warning message
+2020-12-01T12:00:00Z ERROR could not process order err_code=ORDER_NOT_FOUND err_kind=UNEXPECTED order_id=42 root_error="could not process order"
+`
+		if diff := cmp.Diff(expectedText, textOut.String()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should use the Logger level and formatter when the sink doesn't specify them", func(t *testing.T) {
+		var out bytes.Buffer
+
+		logger := NewLogger(LoggerParams{
+			Level: "WARNING",
+			Sinks: []Sink{{Writer: &out}},
+		})
+		logger.now = mockedTimmer()
+
+		logger.Info(ctx, "info message")
+		logger.JSON(ctx, map[string]string{"foo": "bar"}, LevelWarning)
+
+		expected := `{"level":"WARNING","message":"JSON data logged","payload":{"foo":"bar"},"timestamp":"2020-12-01T12:00:00Z"}
+`
+		if diff := cmp.Diff(expected, out.String()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should encode string payloads as JSON, unless they are lines", func(t *testing.T) {
+		var out bytes.Buffer
+
+		logger := NewLogger(LoggerParams{
+			Sinks: []Sink{
+				{Writer: &out, Formatter: formatterMock(func(in format.LogInput) any { return in.Message })},
+				{Writer: &out, Formatter: formatterMock(func(in format.LogInput) any { return format.Line(in.Message) })},
+			},
+		})
+
+		logger.Info(ctx, `say "hi"`)
+
+		expected := `"say \"hi\""
+say "hi"
+`
+		if diff := cmp.Diff(expected, out.String()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("should not interleave entries of sinks sharing the same writer", func(t *testing.T) {
+		out := &concurrencyWriter{}
+
+		logger := NewLogger(LoggerParams{
+			Sinks: []Sink{
+				{Writer: out},
+				{Writer: out, Formatter: format.NewText()},
+			},
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				logger.Info(ctx, "concurrent message")
+			}()
+		}
+		wg.Wait()
+
+		if out.overlaps > 0 {
+			t.Errorf("Expected serialized writes, got %d overlapping ones.", out.overlaps)
+		}
+	})
+}
+
+type formatterMock func(in format.LogInput) any
+
+func (f formatterMock) Format(_ context.Context, in format.LogInput) any {
+	return f(in)
+}
+
+// concurrencyWriter counts the writes that happen while another one is in progress.
+type concurrencyWriter struct {
+	writing  int32
+	overlaps int32
+}
+
+func (w *concurrencyWriter) Write(p []byte) (int, error) {
+	if !atomic.CompareAndSwapInt32(&w.writing, 0, 1) {
+		atomic.AddInt32(&w.overlaps, 1)
+		return len(p), nil
+	}
+
+	time.Sleep(time.Millisecond)
+	atomic.StoreInt32(&w.writing, 0)
+	return len(p), nil
+}
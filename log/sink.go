@@ -0,0 +1,136 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/trivelaapp/go-kit/log/format"
+)
+
+// Sink is a destination of log entries.
+type Sink struct {
+	// Writer receives the log entries, one per line.
+	Writer io.Writer
+	// Level is the minimum severity of entries written to the Sink. Defaults to the Logger level.
+	Level string
+	// Formatter formats the entries written to the Sink. Defaults to the Logger formatter.
+	// Entries formatted as a format.Line are written as they are, while any other entry is written as JSON.
+	Formatter LogFormatter
+}
+
+// sink is a Sink ready to receive entries. Writes are serialized by a lock shared by all sinks with the same writer,
+// since writers are not required to be safe for concurrent use.
+type sink struct {
+	mu        *sync.Mutex
+	writer    io.Writer
+	level     Level
+	formatter LogFormatter
+}
+
+func newSink(s Sink, defaultLevel Level, defaultFormatter LogFormatter, locks writerLocks) sink {
+	level, ok := levelStringValueMap[s.Level]
+	if !ok {
+		level = defaultLevel
+	}
+
+	formatter := s.Formatter
+	if formatter == nil {
+		formatter = defaultFormatter
+	}
+
+	writer := s.Writer
+	if writer == nil {
+		writer = stdout{}
+	}
+
+	return sink{
+		mu:        locks.get(writer),
+		writer:    writer,
+		level:     level,
+		formatter: formatter,
+	}
+}
+
+func (s sink) write(payload any) {
+	var data []byte
+	if line, ok := payload.(format.Line); ok {
+		data = []byte(line)
+	} else {
+		data, _ = json.Marshal(payload)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, _ = s.writer.Write(append(data, '\n'))
+}
+
+// stdout writes to the current os.Stdout, even if it's replaced after the Logger is created.
+type stdout struct{}
+
+// The standard outputs are shared by all Loggers, so their locks are too.
+var stdoutMu, stderrMu sync.Mutex
+
+// writerLocks holds the locks of the writers of a Logger sinks, so sinks sharing a writer never interleave their entries.
+type writerLocks map[io.Writer]*sync.Mutex
+
+func (wl writerLocks) get(writer io.Writer) *sync.Mutex {
+	switch writer {
+	case stdout{}, io.Writer(os.Stdout):
+		return &stdoutMu
+	case io.Writer(os.Stderr):
+		return &stderrMu
+	}
+
+	// Writers that can't be map keys, like the ones with slice fields, get their own lock.
+	if !reflect.TypeOf(writer).Comparable() {
+		return &sync.Mutex{}
+	}
+
+	if _, ok := wl[writer]; !ok {
+		wl[writer] = &sync.Mutex{}
+	}
+	return wl[writer]
+}
+
+func (stdout) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
+// print formats the entry and writes it to every sink that accepts its level.
+// Sinks sharing the same formatter format the entry once, since formatters may record it into the current span.
+func (l Logger) print(ctx context.Context, in format.LogInput, level Level) {
+	type formatted struct {
+		formatter LogFormatter
+		payload   any
+	}
+	cache := []formatted{}
+
+	for _, s := range l.sinks {
+		if s.level < level {
+			continue
+		}
+
+		var payload any
+		cached := false
+		if reflect.TypeOf(s.formatter).Comparable() {
+			for _, f := range cache {
+				if f.formatter == s.formatter {
+					payload, cached = f.payload, true
+					break
+				}
+			}
+		}
+
+		if !cached {
+			payload = s.formatter.Format(ctx, in)
+			cache = append(cache, formatted{formatter: s.formatter, payload: payload})
+		}
+
+		s.write(payload)
+	}
+}